@@ -2,15 +2,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"github.com/thinkwright/simplex/lint/internal/checks"
-	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint"
+	"github.com/thinkwright/simplex/lint/internal/config"
+	"github.com/thinkwright/simplex/lint/internal/gitdiff"
+	"github.com/thinkwright/simplex/lint/internal/plugin"
 	"github.com/thinkwright/simplex/lint/internal/result"
+	"github.com/thinkwright/simplex/lint/internal/snapshot"
 )
 
 // version is set at build time via ldflags
@@ -18,18 +27,30 @@ var version = "dev"
 
 // CLI flags
 var (
-	flagFormat    string
-	flagFix       bool
-	flagNoLLM     bool
-	flagProvider  string
-	flagModel     string
-	flagAPIKey    string
-	flagAPIBase   string
-	flagMaxRules  int
-	flagMaxInputs int
-	flagCache     bool
-	flagNoCache   bool
-	flagVerbose   bool
+	flagFormat       string
+	flagFix          bool
+	flagNoLLM        bool
+	flagProvider     string
+	flagModel        string
+	flagAPIKey       string
+	flagAPIBase      string
+	flagMaxRules     int
+	flagMaxInputs    int
+	flagMaxRuleLen   int
+	flagMaxFunctions int
+	flagCache        bool
+	flagNoCache      bool
+	flagVerbose      bool
+	flagConfig       string
+	flagPreset       string
+	flagFailOn       string
+	flagMaxWarnings  int
+	flagSnapshot     string
+	flagWrite        bool
+	flagChangedSince string
+	flagMulti        bool
+	flagTimings      bool
+	flagTimeout      time.Duration
 )
 
 func main() {
@@ -52,7 +73,38 @@ Examples:
   simplex-lint specs/*.md
   simplex-lint --format json spec.md
   simplex-lint --no-llm spec.md
-  cat spec.md | simplex-lint -`,
+  simplex-lint --config ci/.simplex-lint.yaml spec.md
+  simplex-lint --preset strict specs/*.md
+  simplex-lint --fail-on warning --max-warnings 0 specs/*.md
+  simplex-lint --snapshot lint-snapshot.json --write-snapshot specs/*.md
+  simplex-lint --snapshot lint-snapshot.json specs/*.md
+  simplex-lint --changed-since origin/main specs/*.md
+  simplex-lint --multi auth.simplex compile.simplex deploy.simplex
+  simplex-lint --timings --timeout 30s specs/*.md
+  simplex-lint rules
+  simplex-lint explain E012
+  cat spec.md | simplex-lint -
+
+Configuration:
+  Settings are read from the nearest .simplex-lint.yaml found by walking
+  up from each linted file (or the working directory for stdin).
+  Flags given on the command line override the config file.
+
+Presets:
+  A preset (recommended, strict, compat-v0.3) chooses rules, severities and
+  thresholds; the config file and flags are layered on top. A spec's
+  front-matter status picks its preset (draft → recommended, release →
+  strict, legacy → compat-v0.3) unless --preset is given.
+
+Snapshots:
+  --write-snapshot records every current issue in the --snapshot file.
+  Later runs with --snapshot report only issues not in the snapshot, and
+  drop entries for issues that have been fixed.
+
+Multi-spec analysis:
+  --multi (or data_flow: multi in the config) checks READS, WRITES and
+  TRIGGERS across every input file, so a key one file writes satisfies a
+  read in another. Each finding is reported in the file that declares it.`,
 	Args:    cobra.MinimumNArgs(0),
 	Version: version,
 	RunE:    runLint,
@@ -62,6 +114,28 @@ func init() {
 	// Output options
 	rootCmd.Flags().StringVar(&flagFormat, "format", "text", "Output format: text, json")
 	rootCmd.Flags().BoolVar(&flagVerbose, "verbose", false, "Show detailed check progress")
+	rootCmd.Flags().BoolVar(&flagTimings, "timings", false, "Report how long each check took per file")
+
+	// Run options
+	rootCmd.Flags().DurationVar(&flagTimeout, "timeout", 0, "Abandon checks still running after this long, e.g. 30s (0 disables)")
+
+	// Config options
+	rootCmd.Flags().StringVar(&flagConfig, "config", "", "Config file to use instead of discovering .simplex-lint.yaml")
+	rootCmd.Flags().StringVar(&flagPreset, "preset", "", "Rule preset for every file: recommended, strict, compat-v0.3")
+
+	// Exit code options
+	rootCmd.Flags().StringVar(&flagFailOn, "fail-on", "error", "Least severe issue level that fails the run: error, warning, info")
+	rootCmd.Flags().IntVar(&flagMaxWarnings, "max-warnings", -1, "Fail when the specs have more than N warnings in total (-1 disables)")
+
+	// Snapshot options
+	rootCmd.Flags().StringVar(&flagSnapshot, "snapshot", "", "Snapshot file of known issues; only new issues are reported")
+	rootCmd.Flags().BoolVar(&flagWrite, "write-snapshot", false, "Record all current issues in the --snapshot file")
+
+	// Diff options
+	rootCmd.Flags().StringVar(&flagChangedSince, "changed-since", "", "Only report issues in functions changed since a git ref")
+
+	// Data-flow options
+	rootCmd.Flags().BoolVar(&flagMulti, "multi", false, "Check READS, WRITES and TRIGGERS across all input files")
 
 	// Fix options
 	rootCmd.Flags().BoolVar(&flagFix, "fix", false, "Auto-fix simple issues (disabled by default)")
@@ -76,6 +150,8 @@ func init() {
 	// Threshold options
 	rootCmd.Flags().IntVar(&flagMaxRules, "max-rules", 15, "Override max RULES items")
 	rootCmd.Flags().IntVar(&flagMaxInputs, "max-inputs", 6, "Override max function inputs")
+	rootCmd.Flags().IntVar(&flagMaxRuleLen, "max-rule-length", 200, "Override max characters per RULES item")
+	rootCmd.Flags().IntVar(&flagMaxFunctions, "max-functions", 10, "Override FUNCTION count that triggers W011")
 
 	// Cache options
 	rootCmd.Flags().BoolVar(&flagCache, "cache", true, "Enable result caching")
@@ -86,16 +162,37 @@ func runLint(cmd *cobra.Command, args []string) error {
 	// Apply env var defaults now that cobra has parsed flags
 	applyEnvDefaults()
 
+	if cmd.Flags().Changed("fail-on") {
+		// Case-insensitive, as fail_on is in the config file
+		flagFailOn = strings.ToLower(strings.TrimSpace(flagFailOn))
+		if err := config.ValidateFailOn(flagFailOn); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("preset") {
+		if err := config.ValidatePreset(flagPreset); err != nil {
+			return err
+		}
+	}
+
+	snap, err := loadSnapshot()
+	if err != nil {
+		return err
+	}
+
 	// Determine input sources
 	var inputs []InputSource
 
 	if len(args) == 0 || (len(args) == 1 && args[0] == "-") {
-		// Read from stdin
+		// Read from stdin, which git can't diff
+		if flagChangedSince != "" {
+			return fmt.Errorf("--changed-since needs spec files, not stdin")
+		}
 		content, err := io.ReadAll(os.Stdin)
 		if err != nil {
 			return fmt.Errorf("failed to read stdin: %w", err)
 		}
-		inputs = append(inputs, InputSource{Name: "<stdin>", Content: string(content)})
+		inputs = append(inputs, InputSource{Name: "<stdin>", Content: string(content), Dir: "."})
 	} else {
 		// Read from files
 		for _, path := range args {
@@ -103,23 +200,87 @@ func runLint(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return fmt.Errorf("failed to read %s: %w", path, err)
 			}
-			inputs = append(inputs, InputSource{Name: path, Content: string(content)})
+			input := InputSource{Name: path, Content: string(content), Dir: filepath.Dir(path)}
+			if flagChangedSince != "" {
+				if input.Changes, err = gitdiff.ChangedLines(flagChangedSince, path); err != nil {
+					return fmt.Errorf("--changed-since %s: %w", flagChangedSince, err)
+				}
+			}
+			inputs = append(inputs, input)
+		}
+	}
+
+	// Ctrl-C and --timeout abandon running checks instead of hanging
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	if flagTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flagTimeout)
+		defer cancel()
+	}
+
+	// Resolve the linter for each input's config file
+	loader := config.NewLoader()
+	profiles := make(map[*config.Config]*profile)
+	inputProfiles := make([]*profile, len(inputs))
+	for i, input := range inputs {
+		cfg, err := resolveConfig(loader, input.Dir)
+		if err != nil {
+			return err
+		}
+
+		p, ok := profiles[cfg]
+		if !ok {
+			settings := buildSettings(cmd, cfg)
+			if !flagNoLLM && flagVerbose {
+				// TODO: Implement LLM-based semantic checks in Phase 3/4
+				fmt.Fprintln(os.Stderr, semanticNote(settings))
+			}
+			p = &profile{linter: lint.New(settings.Lint), failPolicy: settings.FailPolicy, multi: settings.Multi}
+			profiles[cfg] = p
 		}
+		inputProfiles[i] = p
 	}
 
-	// Create linter with current configuration
-	linter := NewLinter(LinterConfig{
-		MaxRules:  flagMaxRules,
-		MaxInputs: flagMaxInputs,
-		NoLLM:     flagNoLLM,
-		Verbose:   flagVerbose,
-	})
+	// In multi mode, data flow is checked over one graph of every such input
+	graph := lint.NewFlowGraph()
+	for i, input := range inputs {
+		if inputProfiles[i].multi {
+			graph.Add(input.Name, lint.Parse(input.Content))
+		}
+	}
 
-	// Process each input
 	var results []result.LintResult
-	for _, input := range inputs {
-		r := linter.Lint(input)
+	var policies []result.FailPolicy
+	snapChanged := false
+	for i, input := range inputs {
+		p := inputProfiles[i]
+		var r *result.LintResult
+		if p.multi {
+			r = p.linter.LintGraphContext(ctx, graph, input.Name, input.Content)
+		} else {
+			r = p.linter.LintContext(ctx, input.Name, input.Content)
+		}
+		if snap != nil {
+			if flagWrite {
+				snap.Record(r)
+				snapChanged = true
+			}
+			snap.Filter(r)
+		}
+		// Narrow to changed functions only after the snapshot has seen every
+		// issue, so entries for untouched functions aren't pruned
+		if input.Changes != nil {
+			gitdiff.Filter(lint.Parse(input.Content), input.Changes, r)
+		}
 		results = append(results, *r)
+		policies = append(policies, p.failPolicy)
+	}
+
+	if snapChanged {
+		if err := snap.Write(flagSnapshot); err != nil {
+			return err
+		}
 	}
 
 	// Output results
@@ -129,119 +290,173 @@ func runLint(cmd *cobra.Command, args []string) error {
 		outputMultiple(results, flagFormat)
 	}
 
-	// Exit code based on validity
-	for _, r := range results {
-		if !r.Valid {
-			os.Exit(1)
-		}
+	// Exit code based on each spec's fail policy and the run's warnings
+	if runFails(results, policies) {
+		os.Exit(1)
 	}
 
 	return nil
 }
 
+// runFails reports whether a run fails: a result has an issue at or above
+// its policy's fail-on level, or the results have more warnings in total
+// than the budget of any policy in use. policies[i] applies to results[i].
+func runFails(results []result.LintResult, policies []result.FailPolicy) bool {
+	warnings := 0
+	for i := range results {
+		if policies[i].Fails(&results[i]) {
+			return true
+		}
+		warnings += len(results[i].Warnings)
+	}
+	for _, p := range policies {
+		if p.ExceedsWarnings(warnings) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSnapshot returns the --snapshot file, or nil when snapshots are not in
+// use. With --write-snapshot a missing file starts an empty snapshot.
+func loadSnapshot() (*snapshot.Snapshot, error) {
+	if flagSnapshot == "" {
+		if flagWrite {
+			return nil, fmt.Errorf("--write-snapshot requires --snapshot <file>")
+		}
+		return nil, nil
+	}
+	if flagWrite {
+		return snapshot.LoadOrNew(flagSnapshot)
+	}
+	snap, err := snapshot.Load(flagSnapshot)
+	if err != nil {
+		return nil, fmt.Errorf("%w (create it with --write-snapshot)", err)
+	}
+	return snap, nil
+}
+
 // InputSource represents a spec to be linted.
 type InputSource struct {
 	Name    string
 	Content string
+	Dir     string           // directory config discovery starts from
+	Changes *gitdiff.Changes // lines changed since --changed-since; nil reports everything
 }
 
-// LinterConfig holds configuration for the linter.
-type LinterConfig struct {
-	MaxRules  int
-	MaxInputs int
-	NoLLM     bool
-	Verbose   bool
+// profile is the linter and fail policy for one config file.
+type profile struct {
+	linter     *lint.Linter
+	failPolicy result.FailPolicy
+	multi      bool // lint against the cross-file data-flow graph
 }
 
-// Linter performs linting on Simplex specifications.
-type Linter struct {
-	parser             *parser.Parser
-	structuralChecker  *checks.StructuralChecker
-	complexityChecker  *checks.ComplexityChecker
-	evolutionChecker   *checks.EvolutionChecker
-	determinismChecker *checks.DeterminismChecker
-	config             LinterConfig
+// settings is everything the CLI derives from a config file and flags.
+type settings struct {
+	Lint       lint.Config
+	FailPolicy result.FailPolicy // which findings fail the run
+	Multi      bool              // join the cross-file data-flow graph
+	Provider   string            // LLM provider for semantic checks
+	Model      string            // provider-specific model, empty for its default
 }
 
-// NewLinter creates a new Linter with the given configuration.
-func NewLinter(config LinterConfig) *Linter {
-	complexityConfig := checks.DefaultComplexityConfig()
-	if config.MaxRules > 0 {
-		complexityConfig.MaxRules = config.MaxRules
+// semanticNote tells a verbose run which provider and model the semantic
+// checks would use.
+func semanticNote(s settings) string {
+	provider, model := s.Provider, s.Model
+	if provider == "" {
+		provider = "none"
 	}
-	if config.MaxInputs > 0 {
-		complexityConfig.MaxInputs = config.MaxInputs
+	if model == "" {
+		model = "default"
 	}
+	return fmt.Sprintf("Note: Semantic checks not yet implemented (provider: %s, model: %s)", provider, model)
+}
 
-	return &Linter{
-		parser:             parser.NewParser(),
-		structuralChecker:  checks.NewStructuralChecker(),
-		complexityChecker:  checks.NewComplexityCheckerWithConfig(complexityConfig),
-		evolutionChecker:   checks.NewEvolutionChecker(),
-		determinismChecker: checks.NewDeterminismChecker(),
-		config:             config,
+// resolveConfig returns the config for a file in dir: the --config file when
+// given, otherwise the nearest .simplex-lint.yaml above dir.
+func resolveConfig(loader *config.Loader, dir string) (*config.Config, error) {
+	if flagConfig != "" {
+		return loader.Load(flagConfig)
 	}
+	return loader.ForDir(dir)
 }
 
-// Lint performs all linting checks on the input and returns a result.
-func (l *Linter) Lint(input InputSource) *result.LintResult {
-	r := result.NewLintResult(input.Name)
-
-	// Parse the spec
-	spec := l.parser.Parse(input.Content)
-
-	// Add any parse warnings
-	for _, w := range spec.ParseWarnings {
-		r.AddWarning("W001", w, "parse")
+// buildSettings merges a config file with the command line. Flags the
+// user set explicitly win over the file; provider and model also fall back
+// to their environment variables before the file.
+func buildSettings(cmd *cobra.Command, cfg *config.Config) settings {
+	s := settings{
+		Lint: lint.Config{
+			Preset:        cfg.Preset,
+			StatusPresets: cfg.StatusPresets,
+			EnabledRules:  cfg.Enable,
+			MaxRules:      cfg.Thresholds.MaxRules,
+			MaxInputs:     cfg.Thresholds.MaxInputs,
+			MaxRuleLength: cfg.Thresholds.MaxRuleLength,
+			MaxFunctions:  cfg.Thresholds.MaxFunctions,
+			MaxEvalK:      cfg.Thresholds.MaxEvalK,
+			SpecVersion:   cfg.SpecVersion,
+			Severity:      cfg.Severity,
+			Terms:         lint.Terms(cfg.Terms),
+			BuiltinTypes:  cfg.BuiltinTypes,
+			DataFlow:      lint.DataFlow{External: cfg.DataFlow.External},
+			Timings:       flagTimings,
+		},
+		FailPolicy: cfg.FailPolicy(),
+		Multi:      cfg.DataFlow.Multi,
+		Provider:   flagProvider,
+		Model:      flagModel,
 	}
 
-	// Run all checkers (each handles empty function lists internally)
-	l.structuralChecker.Check(spec, r)
-	l.complexityChecker.Check(spec, r)
-	l.evolutionChecker.Check(spec, r)
-	l.determinismChecker.Check(spec, r)
-
-	// Update stats
-	r.Stats.Functions = len(spec.Functions)
-	r.Stats.Examples = l.countTotalExamples(spec)
-	r.Stats.Branches = l.countTotalBranches(spec)
-
-	// Calculate coverage percent if we have branches
-	if r.Stats.Branches > 0 {
-		r.Stats.CoveragePercent = float64(r.Stats.Examples) / float64(r.Stats.Branches) * 100
+	for code := range cfg.DisabledRules() {
+		s.Lint.DisabledRules = append(s.Lint.DisabledRules, code)
 	}
-
-	// Semantic checks (if LLM enabled)
-	if !l.config.NoLLM {
-		// TODO: Implement LLM-based semantic checks in Phase 3/4
-		if l.config.Verbose {
-			fmt.Fprintln(os.Stderr, "Note: Semantic checks not yet implemented")
-		}
+	sort.Strings(s.Lint.DisabledRules)
+
+	for _, p := range cfg.Plugins {
+		s.Lint.Plugins = append(s.Lint.Plugins, plugin.Plugin{
+			Name:    p.Name,
+			Command: p.Command,
+			Dir:     cfg.Dir(),
+			Timeout: p.TimeoutDuration(),
+		})
 	}
 
-	return r
-}
-
-// countTotalExamples counts all examples across all functions.
-func (l *Linter) countTotalExamples(spec *parser.ParsedSpec) int {
-	total := 0
-	for _, fn := range spec.Functions {
-		if ex := fn.GetExamples(); ex != "" {
-			total += checks.CountExamples(ex)
-		}
+	if cmd.Flags().Changed("preset") {
+		// An explicit preset applies to every file, whatever its status
+		s.Lint.Preset = flagPreset
+		s.Lint.StatusPresets = map[string]string{}
 	}
-	return total
-}
-
-// countTotalBranches counts all branches across all functions.
-func (l *Linter) countTotalBranches(spec *parser.ParsedSpec) int {
-	total := 0
-	for _, fn := range spec.Functions {
-		if rules := fn.GetRules(); rules != "" {
-			total += checks.CountBranches(rules)
-		}
+	if cmd.Flags().Changed("max-rules") {
+		s.Lint.MaxRules = flagMaxRules
+	}
+	if cmd.Flags().Changed("max-inputs") {
+		s.Lint.MaxInputs = flagMaxInputs
 	}
-	return total
+	if cmd.Flags().Changed("max-rule-length") {
+		s.Lint.MaxRuleLength = flagMaxRuleLen
+	}
+	if cmd.Flags().Changed("max-functions") {
+		s.Lint.MaxFunctions = flagMaxFunctions
+	}
+	if cmd.Flags().Changed("multi") {
+		s.Multi = flagMulti
+	}
+	if cmd.Flags().Changed("fail-on") {
+		s.FailPolicy.FailOn = strings.ToLower(strings.TrimSpace(flagFailOn))
+	}
+	if cmd.Flags().Changed("max-warnings") {
+		s.FailPolicy.MaxWarnings = flagMaxWarnings
+	}
+	if s.Provider == "" {
+		s.Provider = cfg.LLM.Provider
+	}
+	if s.Model == "" {
+		s.Model = cfg.LLM.Model
+	}
+
+	return s
 }
 
 func outputSingle(r result.LintResult, format string) {
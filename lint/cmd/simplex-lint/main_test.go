@@ -2,269 +2,22 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"path/filepath"
-	"strings"
+	"runtime"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint"
+	"github.com/thinkwright/simplex/lint/internal/config"
 	"github.com/thinkwright/simplex/lint/internal/result"
+	"github.com/thinkwright/simplex/lint/internal/snapshot"
 )
 
-func TestNewLinter(t *testing.T) {
-	config := LinterConfig{
-		MaxRules:  20,
-		MaxInputs: 8,
-		NoLLM:     true,
-		Verbose:   false,
-	}
-
-	linter := NewLinter(config)
-
-	assert.NotNil(t, linter)
-	assert.NotNil(t, linter.parser)
-	assert.NotNil(t, linter.structuralChecker)
-	assert.NotNil(t, linter.complexityChecker)
-	assert.Equal(t, config, linter.config)
-}
-
-func TestNewLinter_DefaultConfig(t *testing.T) {
-	linter := NewLinter(LinterConfig{})
-
-	assert.NotNil(t, linter)
-	// With zero config, defaults should be applied
-}
-
-func TestLinter_Lint_ValidSpec(t *testing.T) {
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	input := InputSource{
-		Name: "valid.md",
-		Content: `FUNCTION: add(a, b) → sum
-
-RULES:
-  - return the sum of a and b
-
-DONE_WHEN:
-  - result equals a + b
-
-EXAMPLES:
-  (2, 3) → 5
-
-ERRORS:
-  - any error → fail`,
-	}
-
-	result := linter.Lint(input)
-
-	assert.True(t, result.Valid)
-	assert.Empty(t, result.Errors)
-	assert.Equal(t, "valid.md", result.File)
-	assert.Equal(t, 1, result.Stats.Functions)
-	assert.Equal(t, 1, result.Stats.Examples)
-}
-
-func TestLinter_Lint_InvalidSpec_MissingFunction(t *testing.T) {
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	input := InputSource{
-		Name:    "invalid.md",
-		Content: `DATA: SomeType\n  field: string`,
-	}
-
-	result := linter.Lint(input)
-
-	assert.False(t, result.Valid)
-	require.Len(t, result.Errors, 1)
-	assert.Equal(t, "E001", result.Errors[0].Code)
-}
-
-func TestLinter_Lint_InvalidSpec_MissingErrors(t *testing.T) {
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	input := InputSource{
-		Name: "missing_errors.md",
-		Content: `FUNCTION: test() → result
-
-RULES:
-  - do something
-
-DONE_WHEN:
-  - done
-
-EXAMPLES:
-  () → ok`,
-	}
-
-	result := linter.Lint(input)
-
-	assert.False(t, result.Valid)
-	hasE005 := false
-	for _, e := range result.Errors {
-		if e.Code == "E005" {
-			hasE005 = true
-		}
-	}
-	assert.True(t, hasE005, "Expected E005 for missing ERRORS")
-}
-
-func TestLinter_Lint_ComplexityViolations(t *testing.T) {
-	linter := NewLinter(LinterConfig{
-		MaxRules:  3,
-		MaxInputs: 2,
-		NoLLM:     true,
-	})
-
-	input := InputSource{
-		Name: "complex.md",
-		Content: `FUNCTION: complex(a, b, c, d) → result
-
-RULES:
-  - rule 1
-  - rule 2
-  - rule 3
-  - rule 4
-  - rule 5
-
-DONE_WHEN:
-  - done
-
-EXAMPLES:
-  (1, 2, 3, 4) → ok
-
-ERRORS:
-  - fail`,
-	}
-
-	result := linter.Lint(input)
-
-	assert.False(t, result.Valid)
-
-	codes := make(map[string]bool)
-	for _, e := range result.Errors {
-		codes[e.Code] = true
-	}
-	assert.True(t, codes["E010"], "Expected E010 for too many rules")
-	assert.True(t, codes["E011"], "Expected E011 for too many inputs")
-}
-
-func TestLinter_Lint_ParseWarnings(t *testing.T) {
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	input := InputSource{
-		Name: "warnings.md",
-		Content: `FUNCTION: test() → result
-
-RULES:
-  - do something
-
-DONE_WHEN:
-  - done
-
-EXAMPLES:
-  () → ok
-
-ERRORS:
-  - fail
-
-CUSTOM_UNKNOWN_LANDMARK:
-  - this is unrecognized`,
-	}
-
-	result := linter.Lint(input)
-
-	// Should still be valid (unrecognized landmarks are warnings)
-	assert.True(t, result.Valid)
-	assert.NotEmpty(t, result.Warnings)
-
-	hasW001 := false
-	for _, w := range result.Warnings {
-		if w.Code == "W001" {
-			hasW001 = true
-		}
-	}
-	assert.True(t, hasW001, "Expected W001 for unrecognized landmark")
-}
-
-func TestLinter_Lint_Stats(t *testing.T) {
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	input := InputSource{
-		Name: "stats.md",
-		Content: `FUNCTION: fn1() → result
-
-RULES:
-  - if A, do X
-  - if B, do Y
-
-DONE_WHEN:
-  - done
-
-EXAMPLES:
-  (A) → X
-  (B) → Y
-  (C) → Z
-
-ERRORS:
-  - fail
-
-FUNCTION: fn2() → result
-
-RULES:
-  - simple rule
-
-DONE_WHEN:
-  - done
-
-EXAMPLES:
-  () → ok
-
-ERRORS:
-  - fail`,
-	}
-
-	result := linter.Lint(input)
-
-	assert.True(t, result.Valid)
-	assert.Equal(t, 2, result.Stats.Functions)
-	assert.Equal(t, 4, result.Stats.Examples) // 3 + 1
-	assert.True(t, result.Stats.Branches > 0)
-	assert.True(t, result.Stats.CoveragePercent > 0)
-}
-
-func TestLinter_Lint_CoveragePercent_CanExceed100(t *testing.T) {
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	// More examples than branches — coverage should exceed 100%
-	input := InputSource{
-		Name: "overcovered.md",
-		Content: `FUNCTION: test() → result
-
-RULES:
-  - simple rule with no branches
-
-DONE_WHEN:
-  - done
-
-EXAMPLES:
-  (1) → a
-  (2) → b
-  (3) → c
-  (4) → d
-  (5) → e
-
-ERRORS:
-  - fail`,
-	}
-
-	result := linter.Lint(input)
-
-	assert.True(t, result.Valid)
-	// 5 examples / 1 branch = 500%
-	assert.Equal(t, 500.0, result.Stats.CoveragePercent)
-}
-
 func TestOutputSingle_Text(t *testing.T) {
 	r := result.NewLintResult("test.md")
 
@@ -357,193 +110,240 @@ func TestOutputMultiple_JSON(t *testing.T) {
 	assert.Contains(t, output, `"total_valid": 2`)
 }
 
-// Integration tests using actual test fixtures
-func TestIntegration_ValidMinimal(t *testing.T) {
-	content, err := os.ReadFile("../../testdata/valid_minimal.md")
-	require.NoError(t, err)
+// newThresholdCmd returns a command with the threshold flags registered so
+// tests can exercise explicit-flag precedence without touching rootCmd.
+func newThresholdCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().IntVar(&flagMaxRules, "max-rules", 15, "")
+	cmd.Flags().IntVar(&flagMaxInputs, "max-inputs", 6, "")
+	cmd.Flags().IntVar(&flagMaxRuleLen, "max-rule-length", 200, "")
+	cmd.Flags().IntVar(&flagMaxFunctions, "max-functions", 10, "")
+	cmd.Flags().StringVar(&flagFailOn, "fail-on", "error", "")
+	cmd.Flags().IntVar(&flagMaxWarnings, "max-warnings", -1, "")
+	cmd.Flags().StringVar(&flagPreset, "preset", "", "")
+	cmd.Flags().BoolVar(&flagMulti, "multi", false, "")
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
 
-	linter := NewLinter(LinterConfig{NoLLM: true})
-	result := linter.Lint(InputSource{
-		Name:    "valid_minimal.md",
-		Content: string(content),
-	})
+func TestBuildSettings_FileValues(t *testing.T) {
+	cmd := newThresholdCmd(t)
+	cfg, err := config.Parse([]byte(`
+spec_version: "0.4"
+thresholds:
+  max_rules: 20
+  max_inputs: 8
+  max_rule_length: 300
+  max_functions: 3
+  max_eval_k: 10
+disable: [W011]
+severity:
+  E012: warning
+terms:
+  vague: [handled]
+builtin_types: [Money]
+data_flow:
+  external: [env.]
+  multi: true
+llm:
+  provider: ollama
+  model: llama3
+`))
+	require.NoError(t, err)
 
-	assert.True(t, result.Valid)
-	assert.Empty(t, result.Errors)
+	s := buildSettings(cmd, cfg)
+
+	assert.Equal(t, 20, s.Lint.MaxRules)
+	assert.Equal(t, 8, s.Lint.MaxInputs)
+	assert.Equal(t, 300, s.Lint.MaxRuleLength)
+	assert.Equal(t, 3, s.Lint.MaxFunctions)
+	assert.Equal(t, 10, s.Lint.MaxEvalK)
+	assert.Equal(t, "0.4", s.Lint.SpecVersion)
+	assert.Equal(t, []string{"W011"}, s.Lint.DisabledRules)
+	assert.Equal(t, []string{"handled"}, s.Lint.Terms.Vague)
+	assert.Equal(t, []string{"env."}, s.Lint.DataFlow.External)
+	assert.True(t, s.Multi)
+	assert.Equal(t, []string{"Money"}, s.Lint.BuiltinTypes)
+	assert.Equal(t, "warning", s.Lint.Severity["E012"])
+	assert.Equal(t, "ollama", s.Provider)
+	assert.Equal(t, "llama3", s.Model)
 }
 
-func TestIntegration_ValidComplex(t *testing.T) {
-	content, err := os.ReadFile("../../testdata/valid_complex.md")
+func TestBuildSettings_FlagsWin(t *testing.T) {
+	cmd := newThresholdCmd(t, "--max-rules", "30", "--max-rule-length", "120", "--max-functions", "4")
+	cfg, err := config.Parse([]byte("thresholds:\n  max_rules: 20\n  max_inputs: 8\n  max_functions: 12\nllm:\n  provider: ollama"))
 	require.NoError(t, err)
 
-	linter := NewLinter(LinterConfig{NoLLM: true})
-	result := linter.Lint(InputSource{
-		Name:    "valid_complex.md",
-		Content: string(content),
-	})
+	flagProvider = "anthropic"
+	defer func() { flagProvider = "" }()
+
+	s := buildSettings(cmd, cfg)
 
-	assert.True(t, result.Valid)
-	assert.Empty(t, result.Errors)
-	assert.Equal(t, 3, result.Stats.Functions)
+	assert.Equal(t, 30, s.Lint.MaxRules)
+	assert.Equal(t, 8, s.Lint.MaxInputs, "unset flag must not override the file")
+	assert.Equal(t, 120, s.Lint.MaxRuleLength)
+	assert.Equal(t, 4, s.Lint.MaxFunctions)
+	assert.Equal(t, "anthropic", s.Provider)
 }
 
-func TestIntegration_InvalidMissingErrors(t *testing.T) {
-	content, err := os.ReadFile("../../testdata/invalid_missing_errors.md")
+func TestBuildSettings_Multi(t *testing.T) {
+	cfg, err := config.Parse([]byte("data_flow:\n  multi: true"))
 	require.NoError(t, err)
 
-	linter := NewLinter(LinterConfig{NoLLM: true})
-	result := linter.Lint(InputSource{
-		Name:    "invalid_missing_errors.md",
-		Content: string(content),
-	})
-
-	assert.False(t, result.Valid)
-	hasE005 := false
-	for _, e := range result.Errors {
-		if e.Code == "E005" {
-			hasE005 = true
-		}
-	}
-	assert.True(t, hasE005)
+	assert.False(t, buildSettings(newThresholdCmd(t), &config.Config{}).Multi)
+	assert.True(t, buildSettings(newThresholdCmd(t, "--multi"), &config.Config{}).Multi)
+	assert.False(t, buildSettings(newThresholdCmd(t, "--multi=false"), cfg).Multi, "the flag wins over the file")
 }
 
-func TestIntegration_InvalidMissingFunction(t *testing.T) {
-	content, err := os.ReadFile("../../testdata/invalid_missing_function.md")
+func TestBuildSettings_Preset(t *testing.T) {
+	cfg, err := config.Parse([]byte("preset: compat-v0.3\nstatus_presets:\n  release: strict\nenable: [W010]"))
 	require.NoError(t, err)
 
-	linter := NewLinter(LinterConfig{NoLLM: true})
-	result := linter.Lint(InputSource{
-		Name:    "invalid_missing_function.md",
-		Content: string(content),
-	})
-
-	assert.False(t, result.Valid)
-	hasE001 := false
-	for _, e := range result.Errors {
-		if e.Code == "E001" {
-			hasE001 = true
-		}
-	}
-	assert.True(t, hasE001)
-}
+	fromFile := buildSettings(newThresholdCmd(t), cfg)
+	assert.Equal(t, "compat-v0.3", fromFile.Lint.Preset)
+	assert.Equal(t, map[string]string{"release": "strict"}, fromFile.Lint.StatusPresets)
+	assert.Equal(t, []string{"W010"}, fromFile.Lint.EnabledRules)
+	assert.Empty(t, fromFile.Lint.SpecVersion, "the preset chooses the spec version")
 
-func TestIntegration_InvalidTooComplex(t *testing.T) {
-	content, err := os.ReadFile("../../testdata/invalid_too_complex.md")
-	require.NoError(t, err)
+	fromFlag := buildSettings(newThresholdCmd(t, "--preset", "strict"), cfg)
+	assert.Equal(t, "strict", fromFlag.Lint.Preset)
+	assert.NotNil(t, fromFlag.Lint.StatusPresets)
+	assert.Empty(t, fromFlag.Lint.StatusPresets, "--preset overrides front-matter status")
+}
 
-	linter := NewLinter(LinterConfig{NoLLM: true})
-	result := linter.Lint(InputSource{
-		Name:    "invalid_too_complex.md",
-		Content: string(content),
-	})
+func TestResolveConfig_ExplicitPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("thresholds:\n  max_rules: 4"), 0o644))
 
-	assert.False(t, result.Valid)
+	flagConfig = path
+	defer func() { flagConfig = "" }()
 
-	codes := make(map[string]bool)
-	for _, e := range result.Errors {
-		codes[e.Code] = true
-	}
-	assert.True(t, codes["E010"], "Expected E010")
-	assert.True(t, codes["E011"], "Expected E011")
+	cfg, err := resolveConfig(config.NewLoader(), t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, 4, cfg.Thresholds.MaxRules)
 }
 
-func TestIntegration_AllTestdata(t *testing.T) {
-	// Test that all testdata files can be processed without panics
-	files, err := filepath.Glob("../../testdata/*.md")
+func TestResolveConfig_Discovered(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".simplex-lint.yaml"), []byte("thresholds:\n  max_inputs: 2"), 0o644))
+	specs := filepath.Join(root, "specs")
+	require.NoError(t, os.MkdirAll(specs, 0o755))
+
+	cfg, err := resolveConfig(config.NewLoader(), specs)
 	require.NoError(t, err)
-	require.NotEmpty(t, files)
-
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	for _, file := range files {
-		t.Run(filepath.Base(file), func(t *testing.T) {
-			content, err := os.ReadFile(file)
-			require.NoError(t, err)
-
-			// Should not panic
-			result := linter.Lint(InputSource{
-				Name:    filepath.Base(file),
-				Content: string(content),
-			})
-
-			// Valid files should pass, invalid files should fail
-			if strings.HasPrefix(filepath.Base(file), "valid_") {
-				assert.True(t, result.Valid, "Expected %s to be valid", file)
-			} else if strings.HasPrefix(filepath.Base(file), "invalid_") {
-				assert.False(t, result.Valid, "Expected %s to be invalid", file)
-			}
-		})
-	}
+	assert.Equal(t, 2, cfg.Thresholds.MaxInputs)
 }
 
-func TestLinter_Lint_VerboseMode(t *testing.T) {
-	// Test with verbose mode enabled (covers the verbose branch in Lint)
-	linter := NewLinter(LinterConfig{
-		NoLLM:   false, // LLM enabled but not configured
-		Verbose: true,
-	})
+func TestBuildSettings_FailPolicy(t *testing.T) {
+	cfg, err := config.Parse([]byte("fail_on: warning\nmax_warnings: 5"))
+	require.NoError(t, err)
+
+	fromFile := buildSettings(newThresholdCmd(t), cfg)
+	assert.Equal(t, result.FailPolicy{FailOn: "warning", MaxWarnings: 5}, fromFile.FailPolicy)
 
-	input := InputSource{
-		Name: "verbose_test.md",
-		Content: `FUNCTION: test() → result
+	fromFlags := buildSettings(newThresholdCmd(t, "--fail-on", "info", "--max-warnings", "0"), cfg)
+	assert.Equal(t, result.FailPolicy{FailOn: "info", MaxWarnings: 0}, fromFlags.FailPolicy)
 
-RULES:
-  - do something
+	upper := buildSettings(newThresholdCmd(t, "--fail-on", "WARNING"), cfg)
+	assert.Equal(t, "warning", upper.FailPolicy.FailOn)
+}
 
-DONE_WHEN:
-  - done
+func TestSemanticNote_UsesProviderAndModel(t *testing.T) {
+	cfg, err := config.Parse([]byte("llm:\n  provider: anthropic"))
+	require.NoError(t, err)
 
-EXAMPLES:
-  () → ok
+	s := buildSettings(newThresholdCmd(t), cfg)
+	assert.Equal(t, "Note: Semantic checks not yet implemented (provider: anthropic, model: default)", semanticNote(s))
 
-ERRORS:
-  - fail`,
+	s.Model = "claude-sonnet"
+	assert.Contains(t, semanticNote(s), "model: claude-sonnet")
+	assert.Contains(t, semanticNote(settings{}), "provider: none")
+}
+
+func TestRunFails_WarningBudgetAcrossFiles(t *testing.T) {
+	results := make([]result.LintResult, 3)
+	for i, name := range []string{"a.md", "b.md", "c.md"} {
+		r := result.NewLintResult(name)
+		r.AddWarning("W010", "long rule", "FUNCTION f RULES")
+		results[i] = *r
 	}
+	budget := result.FailPolicy{FailOn: "error", MaxWarnings: 2}
+	lenient := result.DefaultFailPolicy()
 
-	// Capture stderr to verify verbose output
-	// Note: This tests the branch but we don't verify stderr content
-	result := linter.Lint(input)
-	assert.True(t, result.Valid)
+	assert.True(t, runFails(results, []result.FailPolicy{budget, budget, budget}), "3 warnings in total, 1 per file")
+	assert.False(t, runFails(results[:2], []result.FailPolicy{budget, budget}))
+	assert.True(t, runFails(results, []result.FailPolicy{lenient, budget, lenient}), "any policy's budget counts the whole run")
+	assert.False(t, runFails(results, []result.FailPolicy{lenient, lenient, lenient}))
+
+	results[1].AddError("E001", "No FUNCTION block found", "spec")
+	assert.True(t, runFails(results, []result.FailPolicy{lenient, lenient, lenient}))
 }
 
-func TestLinter_Lint_ZeroBranches(t *testing.T) {
-	// Test with a spec that has no identifiable branches
-	linter := NewLinter(LinterConfig{NoLLM: true})
+func TestBuildSettings_PluginIssuesFollowRuleOverrides(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "payments.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+echo '{"issues": [{"code": "PAY001", "message": "payment function needs NOT_ALLOWED", "location": "FUNCTION charge", "severity": "error"}]}'
+`), 0o755))
 
-	input := InputSource{
-		Name: "no_branches.md",
-		Content: `FUNCTION: simple() → result
+	cfg, err := config.Parse([]byte("severity:\n  PAY001: warning\nplugins:\n  - name: payments\n    command: [./payments.sh]"))
+	require.NoError(t, err)
+	cfg.Path = filepath.Join(dir, ".simplex-lint.yaml")
+
+	linter := lint.New(buildSettings(newThresholdCmd(t), cfg).Lint)
+	r := linter.Lint("pay.md", `FUNCTION: charge(card, amount) → receipt
 
 RULES:
-  - just do it
+  - charge the card
 
 DONE_WHEN:
-  - done
+  - card charged
 
 EXAMPLES:
-  () → ok
+  (card, 10) → receipt
 
 ERRORS:
-  - fail`,
-	}
+  - any unhandled condition → fail`)
 
-	result := linter.Lint(input)
-	assert.True(t, result.Valid)
-	// With 1 branch (minimum) and 1 example, coverage should be 100%
-	assert.Equal(t, 1, result.Stats.Branches)
+	assert.True(t, r.Valid)
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "PAY001", r.Warnings[0].Code)
+	assert.NotEmpty(t, r.Warnings[0].Fingerprint)
 }
 
-func TestLinter_Lint_EmptySpec(t *testing.T) {
-	linter := NewLinter(LinterConfig{NoLLM: true})
-
-	input := InputSource{
-		Name:    "empty.md",
-		Content: "",
-	}
-
-	result := linter.Lint(input)
-	assert.False(t, result.Valid)
-	assert.Equal(t, 0, result.Stats.Functions)
-	assert.Equal(t, 0, result.Stats.Branches)
-	assert.Equal(t, 0, result.Stats.Examples)
+func TestSnapshot_TimedOutStageLeavesSnapshotUnchanged(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	block := false
+	linter := lint.New(lint.Config{Checks: []lint.Check{{
+		Name:  "payments",
+		Rules: []lint.Rule{{Code: "PAY001", Title: "Payment function missing NOT_ALLOWED", Severity: result.SeverityError}},
+		Run: func(spec *lint.Spec, r *lint.Result) {
+			if block {
+				<-release
+			}
+			r.AddError("PAY001", "payment function has no NOT_ALLOWED", "FUNCTION charge")
+		},
+	}}})
+	spec := "FUNCTION: charge(card) → receipt\n\nRULES:\n  - charge the card\n"
+
+	snap := snapshot.New()
+	snap.Record(linter.Lint("pay.md", spec))
+	require.NotEmpty(t, snap.Files["pay.md"])
+	before := append([]snapshot.Entry(nil), snap.Files["pay.md"]...)
+
+	block = true
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	r := linter.LintContext(ctx, "pay.md", spec)
+	require.Contains(t, r.ToText(), "E091")
+
+	snap.Filter(r)
+	assert.Equal(t, before, snap.Files["pay.md"], "a read-only run never changes the snapshot")
+	snap.Record(r)
+	assert.Equal(t, before, snap.Files["pay.md"], "PAY001 was not fixed, its check timed out")
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/thinkwright/simplex/lint/internal/rules"
+)
+
+var flagRulesFormat string
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List the built-in rules",
+	Long: `List every rule simplex-lint can report with its category, default
+severity and whether --fix can resolve it.
+
+Examples:
+  simplex-lint rules
+  simplex-lint rules --format json
+  simplex-lint rules --format markdown`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeRules(cmd.OutOrStdout(), rules.Builtin(), flagRulesFormat)
+	},
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain CODE",
+	Short: "Explain a rule with example specs",
+	Long: `Explain why a rule exists and show a spec snippet that triggers it
+alongside a corrected one.
+
+Examples:
+  simplex-lint explain E012
+  simplex-lint explain w010 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rule, ok := rules.Lookup(args[0])
+		if !ok {
+			return fmt.Errorf("unknown rule %q; run simplex-lint rules for the list", args[0])
+		}
+		return writeExplanation(cmd.OutOrStdout(), rule, flagRulesFormat)
+	},
+}
+
+func init() {
+	rulesCmd.Flags().StringVar(&flagRulesFormat, "format", "text", "Output format: text, json, markdown")
+	explainCmd.Flags().StringVar(&flagRulesFormat, "format", "text", "Output format: text, json")
+	rootCmd.AddCommand(rulesCmd, explainCmd)
+}
+
+// writeRules prints the rule list as a table, JSON or the docs appendix.
+func writeRules(w io.Writer, list []rules.Rule, format string) error {
+	switch format {
+	case "text":
+		_, err := io.WriteString(w, rules.Table(list))
+		return err
+	case "json":
+		return writeJSON(w, list)
+	case "markdown":
+		_, err := io.WriteString(w, rules.Markdown(list))
+		return err
+	default:
+		return fmt.Errorf("unknown format %q (want text, json or markdown)", format)
+	}
+}
+
+// writeExplanation prints a single rule with its rationale and snippets.
+func writeExplanation(w io.Writer, r rules.Rule, format string) error {
+	switch format {
+	case "text":
+		_, err := io.WriteString(w, rules.Explain(r))
+		return err
+	case "json":
+		return writeJSON(w, r)
+	default:
+		return fmt.Errorf("unknown format %q (want text or json)", format)
+	}
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/rules"
+)
+
+func TestWriteRules_Text(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeRules(&buf, rules.Builtin(), "text"))
+
+	assert.Contains(t, buf.String(), "CODE")
+	assert.Regexp(t, `E005\s+error\s+Structural\s+yes\s+FUNCTION missing ERRORS`, buf.String())
+}
+
+func TestWriteRules_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeRules(&buf, rules.Builtin(), "json"))
+
+	var list []rules.Rule
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &list))
+	assert.Equal(t, rules.Builtin(), list)
+}
+
+func TestWriteRules_UnknownFormat(t *testing.T) {
+	assert.Error(t, writeRules(&bytes.Buffer{}, rules.Builtin(), "yaml"))
+}
+
+func TestWriteExplanation_Text(t *testing.T) {
+	rule, ok := rules.Lookup("E012")
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeExplanation(&buf, rule, "text"))
+
+	out := buf.String()
+	assert.Contains(t, out, "E012: EXAMPLES fewer than branch count")
+	assert.Contains(t, out, "Bad:\n    FUNCTION: pick(a, b)")
+	assert.Contains(t, out, "Good:\n")
+}
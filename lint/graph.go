@@ -0,0 +1,62 @@
+package lint
+
+import (
+	"context"
+	"sync"
+
+	"github.com/thinkwright/simplex/lint/internal/checks"
+)
+
+// FlowGraph links the READS, WRITES and TRIGGERS keys of several specs, so a
+// key written in one file satisfies a read or trigger in another. Add every
+// spec before linting any of them with LintGraphContext.
+type FlowGraph struct {
+	graph *checks.FlowGraph
+
+	mu       sync.Mutex
+	findings map[string][]checks.FlowFinding // by file name, nil until checked
+}
+
+// NewFlowGraph creates an empty cross-file graph.
+func NewFlowGraph() *FlowGraph {
+	return &FlowGraph{graph: checks.NewFlowGraph()}
+}
+
+// Add adds the functions of the spec in file name to the graph.
+func (g *FlowGraph) Add(name string, spec *Spec) {
+	g.graph.Add(name, spec)
+}
+
+// findingsFor returns the graph findings attributed to file name, leaving
+// out keys with one of the external prefixes. The graph is checked once, on
+// first use, so each file's linter can apply its own prefixes; a check cut
+// short by ctx is not kept.
+func (g *FlowGraph) findingsFor(ctx context.Context, name string, external []string) []checks.FlowFinding {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.findings == nil {
+		found := g.graph.CheckContext(ctx, checks.DataFlowConfig{})
+		if ctx.Err() != nil {
+			return nil
+		}
+		g.findings = make(map[string][]checks.FlowFinding)
+		for _, f := range found {
+			g.findings[f.File] = append(g.findings[f.File], f)
+		}
+	}
+
+	var out []checks.FlowFinding
+	for _, f := range g.findings[name] {
+		if !checks.IsExternalKey(f.Key, external) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// LintGraphContext is LintContext for a spec added to g under name: its
+// data-flow findings come from the whole graph instead of the spec alone.
+func (l *Linter) LintGraphContext(ctx context.Context, g *FlowGraph, name, content string) *Result {
+	spec := l.parser.Parse(content)
+	return l.forSpec(spec).lint(ctx, name, spec, g)
+}
@@ -0,0 +1,113 @@
+package checks
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+)
+
+// BaselineItem is one preserve or evolve item of a BASELINE block.
+type BaselineItem struct {
+	Kind  string // "preserve" or "evolve"
+	Index int    // 1-based position within its list
+	Text  string
+
+	// Terms identify the item in example lines.
+	Terms []string
+	// Examples are the 1-based indexes of the examples that exercise it.
+	Examples []int
+}
+
+// ParseBaselineItems returns the preserve and evolve items of a BASELINE
+// block in order. ignore lists words that never identify an item, such as
+// input names.
+func ParseBaselineItems(baseline string, ignore []string) []BaselineItem {
+	var items []BaselineItem
+	counts := map[string]int{}
+	field := ""
+	for _, line := range strings.Split(baseline, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "reference:"):
+			field = "reference"
+		case strings.HasPrefix(trimmed, "preserve:"):
+			field = "preserve"
+		case strings.HasPrefix(trimmed, "evolve:"):
+			field = "evolve"
+		case strings.HasPrefix(trimmed, "-") && (field == "preserve" || field == "evolve"):
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			counts[field]++
+			items = append(items, BaselineItem{
+				Kind:  field,
+				Index: counts[field],
+				Text:  text,
+				Terms: significantTerms(text, ignore),
+			})
+		}
+	}
+	return items
+}
+
+// BaselineCoverage maps each preserve and evolve item of fn's BASELINE to
+// the examples that exercise it. An example tagged "[preserve 2]" exercises
+// that item. Otherwise an example exercises the items it shares a term
+// with, or, when tagged "[preserve]" or "[evolve]", the items of that kind
+// it shares a term with or the only item of that kind.
+func BaselineCoverage(fn parser.FunctionBlock) []BaselineItem {
+	items := ParseBaselineItems(fn.GetBaseline(), fn.Inputs)
+	kinds := map[string]int{}
+	for _, item := range items {
+		kinds[item.Kind]++
+	}
+
+	for i, ex := range ParseExamples(fn.GetExamples()) {
+		words := exampleWords(ex)
+		for j := range items {
+			item := &items[j]
+			if exercises(ex, words, *item, kinds[item.Kind]) {
+				item.Examples = append(item.Examples, i+1)
+			}
+		}
+	}
+	return items
+}
+
+func exercises(ex Example, words map[string]bool, item BaselineItem, ofKind int) bool {
+	tagged := false
+	for _, tag := range ex.Tags {
+		kind, index, _ := strings.Cut(tag, " ")
+		if index != "" {
+			if n, _ := strconv.Atoi(index); kind == item.Kind && n == item.Index {
+				return true
+			}
+		} else if kind == item.Kind {
+			tagged = true
+		}
+	}
+	if len(ex.Tags) > 0 && !tagged {
+		return false // tagged for other items only
+	}
+	if tagged && ofKind == 1 {
+		return true
+	}
+	for _, t := range item.Terms {
+		if words[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// exampleWords returns the stemmed words of an example and its comment,
+// splitting identifiers such as session_mode into their parts.
+func exampleWords(ex Example) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range wordPattern.FindAllString(strings.ToLower(ex.Text+" "+ex.Comment), -1) {
+		words[stem(w)] = true
+		for _, part := range strings.Split(w, "_") {
+			words[stem(part)] = true
+		}
+	}
+	return words
+}
@@ -0,0 +1,61 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+)
+
+func TestParseBaselineItems(t *testing.T) {
+	items := ParseBaselineItems(`reference: "v1"
+preserve:
+  - POST /login returns { session_id }
+  - session timeout is 30 minutes
+evolve:
+  - add JWT issuance`, nil)
+
+	require.Len(t, items, 3)
+	assert.Equal(t, "preserve", items[0].Kind)
+	assert.Equal(t, 2, items[1].Index)
+	assert.Equal(t, []string{"session", "timeout", "30", "minute"}, items[1].Terms)
+	assert.Equal(t, "evolve", items[2].Kind)
+	assert.Equal(t, 1, items[2].Index)
+}
+
+func TestParseExamples_Tags(t *testing.T) {
+	examples := ParseExamples(`[preserve 2] (a) → { ok: true }
+(b) → 2  # [Evolve] new path`)
+
+	require.Len(t, examples, 2)
+	assert.Equal(t, []string{"preserve 2"}, examples[0].Tags)
+	assert.Equal(t, "(a)", examples[0].Input)
+	assert.Equal(t, "{ ok: true }", examples[0].Output)
+	assert.Equal(t, []string{"evolve"}, examples[1].Tags)
+	assert.Equal(t, "new path", examples[1].Comment)
+}
+
+func TestBaselineCoverage(t *testing.T) {
+	spec := parser.NewParser().Parse(`FUNCTION: login(creds) → session
+
+BASELINE:
+  reference: "v1"
+  preserve:
+    - session timeout is 30 minutes
+    - existing client SDKs continue to work
+  evolve:
+    - add JWT issuance
+
+EXAMPLES:
+  (valid_creds, session_mode) → { session_id: "s1" }
+  (valid_creds, jwt_mode) → { token: "t1" }  # [evolve]
+  (v1_client) → { session_id: "s2" }  # [preserve 2]
+`)
+
+	items := BaselineCoverage(spec.Functions[0])
+	require.Len(t, items, 3)
+	assert.Equal(t, []int{1}, items[0].Examples, "shared term session; example 3 names another item")
+	assert.Equal(t, []int{3}, items[1].Examples, "explicit tag")
+	assert.Equal(t, []int{2}, items[2].Examples, "only evolve item, tagged")
+}
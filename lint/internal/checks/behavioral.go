@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// BehavioralConfig holds the term list the behavioral check matches RULES
+// items against (see CompileTerms for the entry syntax).
+type BehavioralConfig struct {
+	// Procedural terms describe steps rather than outcomes.
+	Procedural []string
+}
+
+// DefaultBehavioralConfig returns the built-in term list.
+func DefaultBehavioralConfig() BehavioralConfig {
+	return BehavioralConfig{
+		Procedural: []string{
+			"loop", "loops", "iterate", "iterates", "iterating", "for each",
+			`/\bstep\s+\d+\b/`, "then", `/^(first|next|finally|afterwards)\b/`,
+			"create a variable", "initialize", "initialise", "increment",
+			"decrement",
+		},
+	}
+}
+
+// BehavioralChecker checks that RULES describe outcomes, not procedures,
+// without an LLM.
+type BehavioralChecker struct {
+	procedural []*regexp.Regexp
+}
+
+// NewBehavioralChecker creates a BehavioralChecker with the default terms.
+func NewBehavioralChecker() *BehavioralChecker {
+	return NewBehavioralCheckerWithConfig(DefaultBehavioralConfig())
+}
+
+// NewBehavioralCheckerWithConfig creates a BehavioralChecker with custom
+// terms. Invalid patterns are skipped; validate them with CompileTerms.
+func NewBehavioralCheckerWithConfig(config BehavioralConfig) *BehavioralChecker {
+	return &BehavioralChecker{procedural: mustCompileTerms(config.Procedural)}
+}
+
+// Check flags procedural RULES items. A block where every item is
+// procedural gets E040 per item; procedural items in an otherwise
+// behavioral block get E041.
+// Error E040: RULES item is procedural
+// Error E041: RULES block mixes procedural and behavioral items
+func (c *BehavioralChecker) Check(spec *parser.ParsedSpec, r *result.LintResult) {
+	for _, fn := range spec.Functions {
+		rules := fn.GetRules()
+		if rules == "" {
+			continue
+		}
+		loc := formatFunctionLocation(fn.Name) + " RULES"
+
+		type finding struct {
+			index  int
+			marked string
+		}
+		items := ExtractRuleItems(rules)
+		var procedural []finding
+		for i, item := range items {
+			if _, marked := c.Procedural(item); marked != "" {
+				procedural = append(procedural, finding{i + 1, marked})
+			}
+		}
+
+		mixed := len(procedural) < len(items)
+		for _, f := range procedural {
+			if mixed {
+				r.AddErrorWithSuggestion("E041",
+					fmt.Sprintf("RULES item %d is procedural in a block of behavioral items: %q", f.index, f.marked),
+					loc, "state the outcome this step produces, like the other items", false)
+			} else {
+				r.AddErrorWithSuggestion("E040",
+					fmt.Sprintf("RULES item %d is procedural: %q (RULES should be behavioral, not procedural)", f.index, f.marked),
+					loc, "describe what is true of the output instead of the steps to produce it", false)
+			}
+		}
+	}
+}
+
+// Procedural returns the procedural phrase in a RULES item and the item
+// with that phrase highlighted, or "", "" for a behavioral item. A "then"
+// that follows if, when or unless is a conditional, not a step.
+func (c *BehavioralChecker) Procedural(item string) (phrase, marked string) {
+	_, loc := findTerm(c.procedural, maskConditionalThen(item))
+	if loc == nil {
+		return "", ""
+	}
+	return item[loc[0]:loc[1]], highlight(item, loc)
+}
+
+var conditionalThen = regexp.MustCompile(`(?i)\b(if|when|unless)\b[^.;]*?\bthen\b`)
+
+// maskConditionalThen blanks the "then" of "if X then Y", keeping offsets.
+func maskConditionalThen(item string) string {
+	return conditionalThen.ReplaceAllStringFunc(item, func(m string) string {
+		return m[:len(m)-len("then")] + strings.Repeat(" ", len("then"))
+	})
+}
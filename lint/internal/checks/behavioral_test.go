@@ -0,0 +1,101 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func TestBehavioralChecker_Procedural(t *testing.T) {
+	tests := []struct {
+		item   string
+		phrase string
+	}{
+		{"items matching criteria are included", ""},
+		{"loop through items and add matches", "loop"},
+		{"first, parse the input, then validate", "first"},
+		{"parse the input, then validate it", "then"},
+		{"if the list is empty then return 0", ""},
+		{"Step 2: sort by date", "Step 2"},
+		{"initialize the total to zero", "initialize"},
+		{"output is sorted for each customer", "for each"},
+	}
+
+	c := NewBehavioralChecker()
+	for _, tt := range tests {
+		t.Run(tt.item, func(t *testing.T) {
+			phrase, marked := c.Procedural(tt.item)
+			assert.Equal(t, tt.phrase, phrase)
+			if tt.phrase == "" {
+				assert.Empty(t, marked)
+			} else {
+				assert.Contains(t, marked, "["+tt.phrase+"]")
+			}
+		})
+	}
+}
+
+func TestBehavioralChecker_E040_AllProcedural(t *testing.T) {
+	spec := `FUNCTION: collect(items) → matches
+
+RULES:
+  - loop through items and add matches
+  - then sort the matches
+
+DONE_WHEN:
+  - matches returned
+
+EXAMPLES:
+  ([a]) → [a]
+
+ERRORS:
+  - fail`
+
+	r := result.NewLintResult("test.md")
+	NewBehavioralChecker().Check(parser.NewParser().Parse(spec), r)
+
+	require.Len(t, r.Errors, 2)
+	for _, e := range r.Errors {
+		assert.Equal(t, "E040", e.Code)
+		assert.Equal(t, "FUNCTION collect RULES", e.Location)
+	}
+	assert.Equal(t, `RULES item 1 is procedural: "[loop] through items and add matches" (RULES should be behavioral, not procedural)`, r.Errors[0].Message)
+	assert.Contains(t, r.Errors[1].Message, "RULES item 2")
+}
+
+func TestBehavioralChecker_E041_Mixed(t *testing.T) {
+	spec := `FUNCTION: collect(items) → matches
+
+RULES:
+  - items matching criteria are included
+  - increment the match count for each item
+
+DONE_WHEN:
+  - matches returned
+
+EXAMPLES:
+  ([a]) → [a]
+
+ERRORS:
+  - fail`
+
+	r := result.NewLintResult("test.md")
+	NewBehavioralChecker().Check(parser.NewParser().Parse(spec), r)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E041", r.Errors[0].Code)
+	assert.Equal(t, `RULES item 2 is procedural in a block of behavioral items: "[increment] the match count for each item"`, r.Errors[0].Message)
+}
+
+func TestBehavioralChecker_CustomTerms(t *testing.T) {
+	c := NewBehavioralCheckerWithConfig(BehavioralConfig{Procedural: []string{`/\bfirst \w+, then\b/`}})
+
+	phrase, _ := c.Procedural("first parse, then validate")
+	assert.Equal(t, "first parse, then", phrase)
+
+	phrase, _ = c.Procedural("loop through items")
+	assert.Empty(t, phrase, "configured lists replace the defaults")
+}
@@ -52,6 +52,7 @@ func (c *ComplexityChecker) Check(spec *parser.ParsedSpec, r *result.LintResult)
 		c.checkInputCount(fn, r)
 		c.checkRuleLength(fn, r)
 		c.checkExampleCoverage(fn, r)
+		c.checkExampleConsistency(fn, r)
 	}
 }
 
@@ -113,7 +114,11 @@ func (c *ComplexityChecker) checkRuleLength(fn parser.FunctionBlock, r *result.L
 	}
 }
 
-// checkExampleCoverage checks if examples are fewer than branches.
+// checkExampleCoverage checks that every branch in RULES is exercised by an
+// example. When the branches can't all be identified by their terms, or an
+// uncovered branch isn't decidable from the examples, it falls back to
+// comparing the example count with CountBranches.
+// Error E020: Branch not covered by examples
 // Error E012: EXAMPLES fewer than branch count
 func (c *ComplexityChecker) checkExampleCoverage(fn parser.FunctionBlock, r *result.LintResult) {
 	rules := fn.GetRules()
@@ -124,7 +129,28 @@ func (c *ComplexityChecker) checkExampleCoverage(fn parser.FunctionBlock, r *res
 	}
 
 	branchCount := CountBranches(rules)
-	exampleCount := CountExamples(examples)
+	if branches := ExtractBranches(rules, fn.Inputs); identifiable(branches) {
+		branchCount = max(branchCount, len(branches))
+		parsed := ParseExamples(examples)
+		undecided := false
+		for _, b := range branches {
+			switch {
+			case branchCovered(b, parsed):
+			case b.Decidable(parsed):
+				r.AddError("E020",
+					fmt.Sprintf("branch '%s' (RULES item %d) not covered by examples", b.Text, b.Rule),
+					formatFunctionLocation(fn.Name)+" RULES")
+			default:
+				undecided = true
+			}
+		}
+		if !undecided {
+			return
+		}
+	}
+
+	// A duplicate exercises nothing new (W013)
+	exampleCount := CountExamples(examples) - countDuplicateExamples(fn)
 
 	if exampleCount < branchCount {
 		r.AddError("E012",
@@ -134,6 +160,16 @@ func (c *ComplexityChecker) checkExampleCoverage(fn parser.FunctionBlock, r *res
 	}
 }
 
+// branchCovered reports whether any example exercises b.
+func branchCovered(b Branch, examples []Example) bool {
+	for _, ex := range examples {
+		if b.CoveredBy(ex) {
+			return true
+		}
+	}
+	return false
+}
+
 // CountRuleItems counts the number of rule items in a RULES block.
 // Items are typically marked with - at the start of a line.
 func CountRuleItems(rules string) int {
@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -237,23 +238,158 @@ ERRORS:
 	assert.True(t, hasW011, "Expected W011 warning for many functions")
 }
 
-func TestComplexityChecker_E012_InsufficientExamples(t *testing.T) {
-	spec := `FUNCTION: branching() → result
+func TestComplexityChecker_E020_UncoveredBranches(t *testing.T) {
+	spec := `FUNCTION: branching(n, s) → result
 
 RULES:
-  - if input is A, return X
-  - if input is B, return Y
-  - if input is C or D, return Z
+  - if n is negative, return X
+  - if n is zero, return Y
+  - if s is empty or null, return Z
 
 DONE_WHEN:
   - correct result returned
 
 EXAMPLES:
-  (A) → X
+  (-1, "a") → X
+
+ERRORS:
+  - fail`
+	// Has 4 branches but only the first is exercised
+
+	p := parser.NewParser()
+	parsed := p.Parse(spec)
+
+	r := result.NewLintResult("test.md")
+	checker := NewComplexityChecker()
+	checker.Check(parsed, r)
+
+	var uncovered []string
+	for _, e := range r.Errors {
+		assert.NotEqual(t, "E012", e.Code, "E012 is only the fallback")
+		if e.Code == "E020" {
+			uncovered = append(uncovered, e.Message)
+			assert.Equal(t, "FUNCTION branching RULES", e.Location)
+		}
+	}
+	assert.Equal(t, []string{
+		"branch 'n is zero' (RULES item 2) not covered by examples",
+		"branch 's is empty' (RULES item 3) not covered by examples",
+		"branch 'null' (RULES item 3) not covered by examples",
+	}, uncovered)
+}
+
+func TestComplexityChecker_E020_SharedOutcome(t *testing.T) {
+	spec := `FUNCTION: check(n) → status
+
+RULES:
+  - if n is negative or zero, return error
+  - if n is positive, return ok
+
+DONE_WHEN:
+  - status returned
+
+EXAMPLES:
+  (-1) → error
+  (5) → ok
+
+ERRORS:
+  - fail`
+	// (-1) → error must not also cover the zero branch through its outcome
+
+	r := result.NewLintResult("test.md")
+	NewComplexityChecker().Check(parser.NewParser().Parse(spec), r)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E020", r.Errors[0].Code)
+	assert.Equal(t, "branch 'zero' (RULES item 1) not covered by examples", r.Errors[0].Message)
+}
+
+func TestComplexityChecker_E012_FallbackForUndecidableBranches(t *testing.T) {
+	spec := `FUNCTION: add_to_cart(cart, item) → Cart
+
+RULES:
+  - if item already in cart, increase quantity, otherwise add item to cart
+
+DONE_WHEN:
+  - item is in cart
+
+EXAMPLES:
+  (empty_cart, item_a) → { items: [item_a] }
+%s
+ERRORS:
+  - fail`
+	// No example says "already", so matching can't tell whether
+	// (cart_with_a, item_a) exercises the first branch
+
+	p := parser.NewParser()
+
+	r := result.NewLintResult("test.md")
+	NewComplexityChecker().Check(p.Parse(fmt.Sprintf(spec, "  (cart_with_a, item_a) → { items: [item_a(qty:2)] }")), r)
+	assert.Empty(t, r.Errors, "as many examples as branches")
+
+	r = result.NewLintResult("test.md")
+	NewComplexityChecker().Check(p.Parse(fmt.Sprintf(spec, "")), r)
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E012", r.Errors[0].Code)
+}
+
+func TestComplexityChecker_E020_ExamplesOnOneBranch(t *testing.T) {
+	spec := `FUNCTION: sign(n) → label
+
+RULES:
+  - if n is negative, return "negative"
+  - if n is zero, return "zero"
+  - otherwise return "positive"
+
+DONE_WHEN:
+  - label returned
+
+EXAMPLES:
+  (1) → "positive"
+  (2) → "positive"
+  (3) → "positive"
+  (40) → "positive"
+  (500) → "positive"
+
+ERRORS:
+  - fail`
+	// More examples than branches, but all on the same branch
+
+	p := parser.NewParser()
+	parsed := p.Parse(spec)
+
+	r := result.NewLintResult("test.md")
+	NewComplexityChecker().Check(parsed, r)
+
+	var uncovered []string
+	for _, e := range r.Errors {
+		if e.Code == "E020" {
+			uncovered = append(uncovered, e.Message)
+		}
+	}
+	assert.Equal(t, []string{
+		"branch 'n is negative' (RULES item 1) not covered by examples",
+		"branch 'n is zero' (RULES item 2) not covered by examples",
+	}, uncovered)
+}
+
+func TestComplexityChecker_E012_FallbackForUnidentifiableBranches(t *testing.T) {
+	spec := `FUNCTION: pick(a, b) → result
+
+RULES:
+  - if a, return a
+  - if b, return b
+  - otherwise return nothing
+
+DONE_WHEN:
+  - correct result returned
+
+EXAMPLES:
+  (1, _) → 1
 
 ERRORS:
   - fail`
-	// Has 4 branches but only 1 example
+	// "if a" has no terms besides the input name, so branches can't be matched
 
 	p := parser.NewParser()
 	parsed := p.Parse(spec)
@@ -264,6 +400,7 @@ ERRORS:
 
 	hasE012 := false
 	for _, e := range r.Errors {
+		assert.NotEqual(t, "E020", e.Code)
 		if e.Code == "E012" {
 			hasE012 = true
 			assert.Contains(t, e.Message, "1 items")
@@ -0,0 +1,218 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// normalizedExample is an example reduced to canonical text, so that
+// ("a",  1.0) and ('a', 1) compare equal.
+type normalizedExample struct {
+	Input  string
+	Output string
+	// Given is the state a "# given ..." comment describes, which the
+	// input doesn't show, e.g. "a card on file".
+	Given string
+	// Stable is Output without the fields DETERMINISM lets vary.
+	Stable string
+}
+
+// normalizeExamples normalizes fn's examples in order. Named arguments are
+// put in signature order and object fields sorted; scalars are written one
+// way ("a" for 'a', 1 for 1.0, null for nil); other text has its whitespace
+// collapsed.
+func normalizeExamples(fn parser.FunctionBlock) []normalizedExample {
+	inputs, _ := signatureInputs(fn)
+	var vary [][]string
+	if fn.HasDeterminism() {
+		for _, v := range ParseDeterminism(fn.GetDeterminism()).Vary {
+			if fieldRefPattern.MatchString(v) {
+				vary = append(vary, strings.Split(v, "."))
+			}
+		}
+	}
+
+	var out []normalizedExample
+	for _, ex := range ParseExamples(fn.GetExamples()) {
+		n := normalizedExample{
+			Input: normalizeInput(strings.TrimPrefix(ex.Input, fn.Name), inputs),
+			Given: givenState(ex.Comment),
+		}
+		if v, ok := ParseValue(ex.Output); ok {
+			n.Output = canonicalValue(v)
+			for _, path := range vary {
+				v = withoutField(v, path)
+			}
+			n.Stable = canonicalValue(v)
+		} else {
+			n.Output = collapseSpace(ex.Output)
+			n.Stable = n.Output
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+// normalizeInput returns the canonical text of an example input.
+func normalizeInput(input string, inputs []signatureInput) string {
+	args, ok := ParseArguments(input)
+	if !ok {
+		if v, ok := ParseValue(input); ok {
+			return canonicalValue(v)
+		}
+		return collapseSpace(input)
+	}
+
+	order := make(map[string]int, len(inputs))
+	for i, in := range inputs {
+		order[in.name] = i
+	}
+	named := make([]Argument, 0, len(args))
+	var parts []string
+	for _, a := range args {
+		if a.Name == "" {
+			parts = append(parts, canonicalValue(a.Value))
+		} else {
+			named = append(named, a)
+		}
+	}
+	sort.SliceStable(named, func(i, j int) bool {
+		oi, iok := order[named[i].Name]
+		oj, jok := order[named[j].Name]
+		if iok && jok {
+			return oi < oj
+		}
+		return iok && !jok
+	})
+	for _, a := range named {
+		// A named argument in its positional slot reads the same either way
+		if i, ok := order[a.Name]; ok && i == len(parts) {
+			parts = append(parts, canonicalValue(a.Value))
+		} else {
+			parts = append(parts, a.Name+": "+canonicalValue(a.Value))
+		}
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// canonicalValue writes v in one canonical form.
+func canonicalValue(v Value) string {
+	switch v.Kind {
+	case ValueObject:
+		fields := make([]string, len(v.Fields))
+		for i, f := range v.Fields {
+			fields[i] = f.Key + ": " + canonicalValue(f.Value)
+		}
+		sort.Strings(fields)
+		return "{" + strings.Join(fields, ", ") + "}"
+	case ValueList:
+		items := make([]string, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = canonicalValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case ValueString:
+		return strconv.Quote(v.Text[1 : len(v.Text)-1])
+	case ValueNumber:
+		if f, err := strconv.ParseFloat(v.Text, 64); err == nil {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	case ValueBool:
+		return strings.ToLower(v.Text)
+	case ValueNull:
+		return "null"
+	}
+	return collapseSpace(v.Text)
+}
+
+// withoutField returns v with the dotted field path removed, following
+// nested objects and list items.
+func withoutField(v Value, path []string) Value {
+	switch v.Kind {
+	case ValueList:
+		items := make([]Value, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = withoutField(item, path)
+		}
+		v.Items = items
+	case ValueObject:
+		var fields []ObjectField
+		for _, f := range v.Fields {
+			switch {
+			case f.Key != path[0]:
+				fields = append(fields, f)
+			case len(path) > 1:
+				fields = append(fields, ObjectField{Key: f.Key, Value: withoutField(f.Value, path[1:])})
+			}
+		}
+		v.Fields = fields
+	}
+	return v
+}
+
+// givenPattern matches the marker of a comment that describes state the
+// example input doesn't show: "# given a card on file".
+var givenPattern = regexp.MustCompile(`(?i)^given\b[:\s]*`)
+
+// givenState returns the state a "given ..." comment describes, or "".
+func givenState(comment string) string {
+	if loc := givenPattern.FindStringIndex(comment); loc != nil {
+		return strings.ToLower(collapseSpace(comment[loc[1]:]))
+	}
+	return ""
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// checkExampleConsistency compares examples with the same normalized input.
+// Two that also have the same output are duplicates; two whose outputs
+// differ outside the DETERMINISM vary fields conflict, unless both have a
+// "# given ..." comment and the states they describe differ.
+// Error E013: Examples with the same input have different outputs
+// Warning W013: Example duplicates an earlier example
+func (c *ComplexityChecker) checkExampleConsistency(fn parser.FunctionBlock, r *result.LintResult) {
+	loc := formatFunctionLocation(fn.Name) + " EXAMPLES"
+	examples := normalizeExamples(fn)
+
+	for j, b := range examples {
+		conflict := -1
+		duplicate := false
+		for i, a := range examples[:j] {
+			if a.Input != b.Input {
+				continue
+			}
+			if a.Output == b.Output {
+				r.AddWarningWithSuggestion("W013",
+					fmt.Sprintf("example %d duplicates example %d", j+1, i+1), loc,
+					"remove it, or change its input to exercise another case", false)
+				duplicate = true
+				break
+			}
+			if conflict < 0 && a.Stable != b.Stable && (a.Given == "" || b.Given == "" || a.Given == b.Given) {
+				conflict = i
+			}
+		}
+		if !duplicate && conflict >= 0 {
+			r.AddError("E013",
+				fmt.Sprintf("examples %d and %d have the same input %s but different outputs", conflict+1, j+1, b.Input), loc)
+		}
+	}
+}
+
+// countDuplicateExamples counts fn's examples that repeat an earlier one.
+func countDuplicateExamples(fn parser.FunctionBlock) int {
+	examples := normalizeExamples(fn)
+	seen := make(map[[2]string]bool, len(examples))
+	for _, ex := range examples {
+		seen[[2]string{ex.Input, ex.Output}] = true
+	}
+	return len(examples) - len(seen)
+}
@@ -0,0 +1,107 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func checkConsistency(t *testing.T, spec string) *result.LintResult {
+	t.Helper()
+	parsed := parser.NewParser().Parse(spec)
+	require.Len(t, parsed.Functions, 1)
+	r := result.NewLintResult("test.md")
+	NewComplexityChecker().checkExampleConsistency(parsed.Functions[0], r)
+	return r
+}
+
+func issueLines(r *result.LintResult) []string {
+	var lines []string
+	for _, e := range r.Issues() {
+		lines = append(lines, e.Code+" "+e.Message)
+	}
+	return lines
+}
+
+func TestExampleConsistency_ConflictsAndDuplicates(t *testing.T) {
+	r := checkConsistency(t, `FUNCTION: add_to_cart(cart, item) → cart
+
+EXAMPLES:
+  (empty, "apple") → ["apple"]
+  (empty,   'apple') → ['apple']
+  (item: "apple", cart: empty) → ["pear"]
+  (full, "apple") → { total: 2.0, items: 2 }
+  (full, "apple") → {items: 2, total: 2}
+  (full, "apple") → Error:   cart is full`)
+
+	assert.Equal(t, []string{
+		`E013 examples 1 and 3 have the same input (empty, "apple") but different outputs`,
+		`E013 examples 4 and 6 have the same input (full, "apple") but different outputs`,
+		"W013 example 2 duplicates example 1",
+		"W013 example 5 duplicates example 4",
+	}, issueLines(r))
+	assert.Equal(t, "FUNCTION add_to_cart EXAMPLES", r.Errors[0].Location)
+}
+
+func TestExampleConsistency_HonorsVary(t *testing.T) {
+	r := checkConsistency(t, `FUNCTION: login(credentials) → Session
+
+DETERMINISM:
+  level: structural
+  vary: session_id, meta.issued_at
+  stable: user
+
+EXAMPLES:
+  (valid) → { user: "ann", session_id: "a1", meta: { issued_at: 1, region: "eu" } }
+  (valid) → { user: "ann", session_id: "b2", meta: { issued_at: 2, region: "eu" } }
+  (valid) → { user: "bob", session_id: "c3", meta: { issued_at: 3, region: "eu" } }`)
+
+	assert.Equal(t, []string{`E013 examples 1 and 3 have the same input (valid) but different outputs`}, issueLines(r),
+		"examples 1 and 2 differ only in vary fields")
+}
+
+func TestExampleConsistency_CommentsDontExempt(t *testing.T) {
+	r := checkConsistency(t, `FUNCTION: add(a, b) → sum
+
+EXAMPLES:
+  (2, 3) → 5  # small numbers
+  (2, 3) → 6  # also small numbers`)
+
+	assert.Equal(t, []string{`E013 examples 1 and 2 have the same input (2, 3) but different outputs`}, issueLines(r))
+}
+
+func TestExampleConsistency_GivenState(t *testing.T) {
+	r := checkConsistency(t, `FUNCTION: checkout(cart) → receipt
+
+EXAMPLES:
+  (cart) → receipt  # given a card on file
+  (cart) → Error: no payment method  # Given: no card on file`)
+
+	assert.Empty(t, r.Issues(), "the comments describe different unshown state")
+
+	r = checkConsistency(t, `FUNCTION: checkout(cart) → receipt
+
+EXAMPLES:
+  (cart) → receipt  # given a card on file
+  (cart) → Error: no payment method  # expired card
+  (cart) → Error: card declined  # given a card on file`)
+
+	assert.Equal(t, []string{
+		`E013 examples 1 and 2 have the same input (cart) but different outputs`,
+		`E013 examples 1 and 3 have the same input (cart) but different outputs`,
+	}, issueLines(r), "both examples need the marker, and the same state conflicts")
+}
+
+func TestCountDuplicateExamples(t *testing.T) {
+	parsed := parser.NewParser().Parse(`FUNCTION: sign(n) → label
+
+EXAMPLES:
+  (1) → "positive"
+  (1.0) → 'positive'
+  (-1) → "negative"`)
+
+	assert.Equal(t, 1, countDuplicateExamples(parsed.Functions[0]))
+}
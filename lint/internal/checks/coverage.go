@@ -0,0 +1,382 @@
+package checks
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Branch is one conditional path through a RULES item.
+type Branch struct {
+	Text string // e.g., "n is zero" or "otherwise: fail"
+	Rule int    // 1-based index of the RULES item it comes from
+
+	// Terms identify the branch's condition in example inputs and comments.
+	Terms []string
+	// Negated is set when the condition is phrased negatively ("is not a
+	// number"), so literal patterns such as digits for "number" don't count.
+	Negated bool
+	// Excludes are the condition terms of the sibling branches an
+	// "otherwise" or "without" branch is the negation of. Such a branch is
+	// exercised by an example that meets none of those conditions.
+	Excludes [][]string
+	// Outcome identifies an "otherwise" branch in example comments, which
+	// may name it ("# flat domestic rate") instead of its siblings.
+	Outcome []string
+}
+
+// Example is one line of an EXAMPLES block split into its parts.
+type Example struct {
+	Text    string // the whole example, without its comment
+	Input   string // text before the arrow, e.g. "(2, 3)"
+	Output  string // text after the arrow
+	Comment string // trailing "# ..." or "// ..." comment and preceding comment lines
+	// Tags are the BASELINE tags on the line, e.g. "preserve" or "evolve 2".
+	Tags []string
+}
+
+// ParseExamples splits an EXAMPLES block into examples. Lines that start with
+// "(" or contain an arrow are examples, as in CountExamples; comment lines
+// are attached to the example that follows them.
+func ParseExamples(examples string) []Example {
+	var out []Example
+	var pending []string
+
+	for _, line := range strings.Split(examples, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+			pending = append(pending, strings.TrimLeft(trimmed, "#/ "))
+			continue
+		}
+		if trimmed[0] != '(' && !strings.Contains(trimmed, "→") && !strings.Contains(trimmed, "->") {
+			continue
+		}
+
+		var tags []string
+		for _, m := range exampleTagPattern.FindAllStringSubmatch(trimmed, -1) {
+			tags = append(tags, strings.TrimSpace(strings.ToLower(m[1])+" "+m[2]))
+		}
+		trimmed = strings.TrimSpace(exampleTagPattern.ReplaceAllString(trimmed, ""))
+
+		text, comment := splitComment(trimmed)
+		ex := Example{Text: text, Input: text, Tags: tags}
+		if idx := arrowPattern.FindStringIndex(text); idx != nil {
+			ex.Input = strings.TrimSpace(text[:idx[0]])
+			ex.Output = strings.TrimSpace(text[idx[1]:])
+		}
+		ex.Comment = strings.TrimSpace(strings.Join(append(pending, comment), " "))
+		pending = nil
+		out = append(out, ex)
+	}
+	return out
+}
+
+var (
+	arrowPattern = regexp.MustCompile(`→|->`)
+	// exampleTagPattern matches "[preserve]", "[evolve]" or, naming the
+	// item, "[preserve 2]" anywhere on an example line.
+	exampleTagPattern = regexp.MustCompile(`(?i)\[\s*(preserve|evolve)\s*(\d*)\s*\]`)
+)
+
+// splitComment separates a trailing " # ..." or " // ..." comment that is not
+// inside a quoted string.
+func splitComment(line string) (text, comment string) {
+	inQuote := byte(0)
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case inQuote != 0:
+			if ch == inQuote {
+				inQuote = 0
+			}
+		case ch == '"' || ch == '\'':
+			inQuote = ch
+		case i > 0 && line[i-1] == ' ' && (ch == '#' || strings.HasPrefix(line[i:], "//")):
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(strings.TrimLeft(line[i:], "#/ "))
+		}
+	}
+	return line, ""
+}
+
+// Branch extraction patterns, matched against a lower-cased RULES item.
+var (
+	eitherPattern   = regexp.MustCompile(`\beither\b(.+?)\bor\b([^,;]+)`)
+	conditionStart  = regexp.MustCompile(`\b(if|when)\b`)
+	conditionEnd    = regexp.MustCompile(`,|;|:|\bthen\b|→|->`)
+	otherwisePrefix = regexp.MustCompile(`\b(otherwise|else)\b[,:]?`)
+	optionalPattern = regexp.MustCompile(`\boptionally\b([^,;]*)`)
+	orSplit         = regexp.MustCompile(`\s+or\s+`)
+	negationPattern = regexp.MustCompile(`\b(not|no|non|neither|nor|never|without|isn't|aren't|doesn't)\b|\bnon-`)
+)
+
+// ExtractBranches identifies the conditional branches of a RULES block,
+// following the patterns CountBranches counts:
+//   - "if X" / "when X" → branch X
+//   - "if X or Y" → branches X and Y
+//   - "if X, ... otherwise Y" → branches X and "otherwise: Y"
+//   - "either X or Y" → branches X and Y
+//   - "optionally X" → branches "with X" and "without X"
+//
+// ignore lists words that never identify a branch, such as input names.
+func ExtractBranches(rules string, ignore []string) []Branch {
+	var branches []Branch
+	for i, item := range ExtractRuleItems(rules) {
+		branches = append(branches, itemBranches(strings.ToLower(item), i+1, ignore)...)
+	}
+	return branches
+}
+
+func itemBranches(item string, rule int, ignore []string) []Branch {
+	if m := eitherPattern.FindStringSubmatch(item); m != nil {
+		var out []Branch
+		for _, alt := range m[1:] {
+			alt = strings.TrimSpace(alt)
+			out = append(out, Branch{Text: alt, Rule: rule, Terms: significantTerms(alt, ignore)})
+		}
+		return out
+	}
+
+	var out []Branch
+	rest := item
+	for {
+		loc := conditionStart.FindStringIndex(rest)
+		if loc == nil {
+			break
+		}
+		after := rest[loc[1]:]
+		condition, consequence := after, ""
+		if end := conditionEnd.FindStringIndex(after); end != nil {
+			condition, consequence = after[:end[0]], after[end[1]:]
+		}
+
+		// The consequence runs until an "otherwise" or the next condition
+		elseText := ""
+		if other := otherwisePrefix.FindStringIndex(consequence); other != nil {
+			elseText = strings.TrimSpace(consequence[other[1]:])
+			consequence = consequence[:other[0]]
+		}
+		remaining := ""
+		if next := conditionStart.FindStringIndex(consequence); next != nil {
+			remaining = consequence[next[0]:]
+			consequence = consequence[:next[0]]
+		}
+
+		var siblings [][]string
+		for _, alt := range orSplit.Split(strings.TrimSpace(condition), -1) {
+			if alt == "" {
+				continue
+			}
+			terms := significantTerms(alt, ignore)
+			siblings = append(siblings, terms)
+			out = append(out, Branch{
+				Text: alt, Rule: rule, Terms: terms,
+				Negated: negationPattern.MatchString(alt),
+			})
+		}
+
+		if elseText != "" {
+			out = append(out, Branch{
+				Text:     "otherwise: " + strings.TrimRight(elseText, ". "),
+				Rule:     rule,
+				Excludes: siblings,
+				Outcome:  significantTerms(elseText, ignore),
+			})
+		}
+		if remaining == "" {
+			break
+		}
+		rest = remaining
+	}
+	if len(out) > 0 {
+		return out
+	}
+
+	if m := optionalPattern.FindStringSubmatch(item); m != nil {
+		what := strings.TrimSpace(m[1])
+		terms := significantTerms(what, ignore)
+		return []Branch{
+			{Text: "with " + what, Rule: rule, Terms: terms},
+			{Text: "without " + what, Rule: rule, Excludes: [][]string{terms}},
+		}
+	}
+	return nil
+}
+
+// identifiable reports whether there are branches and each can be told
+// apart from the others by its condition: it has terms of its own, or it is
+// an otherwise branch with siblings to exclude.
+func identifiable(branches []Branch) bool {
+	seen := make(map[string]bool, len(branches))
+	for _, b := range branches {
+		if len(b.Excludes) > 0 {
+			continue
+		}
+		if len(b.Terms) == 0 {
+			return false
+		}
+		terms := append([]string(nil), b.Terms...)
+		sort.Strings(terms)
+		key := strings.Join(terms, " ")
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+	}
+	return len(branches) > 0
+}
+
+// CoveredBy reports whether an example exercises the branch: all condition
+// terms appear in its input or comment, or, for an otherwise branch, its
+// comment names the branch or the example meets none of the sibling
+// conditions. Outputs are not matched, since sibling branches often share
+// an outcome.
+func (b Branch) CoveredBy(ex Example) bool {
+	in := ex.Input + " " + ex.Comment
+	if len(b.Excludes) == 0 {
+		return matchesAll(b.Terms, in, !b.Negated)
+	}
+	if matchesAny(b.Outcome, ex.Comment, false) {
+		return true
+	}
+	for _, terms := range b.Excludes {
+		if matchesAll(terms, in, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// Decidable reports whether the examples can show that b is not covered:
+// one of its terms has a literal pattern, such as digits for "negative", or
+// appears in some example's input or comment. A branch whose terms appear
+// nowhere, e.g. "item already in cart" against (cart_with_a, item_a), may
+// be exercised by an example that just doesn't say so. Otherwise branches
+// are always decidable.
+func (b Branch) Decidable(examples []Example) bool {
+	if len(b.Excludes) > 0 {
+		return true
+	}
+	for _, t := range b.Terms {
+		if _, ok := termPatterns[t]; ok && !b.Negated {
+			return true
+		}
+		for _, ex := range examples {
+			if matchesAny([]string{t}, ex.Input+" "+ex.Comment, false) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// termPatterns recognize condition words in example literals, so "n is zero"
+// matches (0) and "list is empty" matches ([]).
+var termPatterns = map[string]*regexp.Regexp{
+	"negative": regexp.MustCompile(`(^|[^\w.])-\d`),
+	"zero":     regexp.MustCompile(`(^|[^\w.])0(\.0+)?\b`),
+	"positive": regexp.MustCompile(`(^|[^\w.-])[1-9]`),
+	"empty":    regexp.MustCompile(`""|''|\[\s*\]|\{\s*\}|\(\s*\)|\bempty\b`),
+	"null":     regexp.MustCompile(`\b(null|nil|none|missing|undefined)\b`),
+	"missing":  regexp.MustCompile(`\b(null|nil|none|missing|absent|undefined)\b|""|\(\s*\)`),
+	"fail":     regexp.MustCompile(`(?i)\b(error|fail\w*|invalid|reject\w*|raise\w*)\b`),
+	"failure":  regexp.MustCompile(`(?i)\b(error|fail\w*|invalid|reject\w*|raise\w*)\b`),
+	"error":    regexp.MustCompile(`(?i)\b(error|fail\w*|invalid|reject\w*|raise\w*)\b`),
+	"number":   regexp.MustCompile(`(^|[^\w.])-?\d`),
+	"numeric":  regexp.MustCompile(`(^|[^\w.])-?\d`),
+	"integer":  regexp.MustCompile(`(^|[^\w.])-?\d+\b`),
+	"string":   regexp.MustCompile(`"[^"]*"|'[^']*'`),
+	"list":     regexp.MustCompile(`\[`),
+}
+
+// matchesAll reports whether every term matches text, as in matchesAny.
+func matchesAll(terms []string, text string, literals bool) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	for _, t := range terms {
+		if !matchesAny([]string{t}, text, literals) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether any term occurs in text as a word (after the
+// same normalization as significantTerms) or, if literals is set, through
+// termPatterns.
+func matchesAny(terms []string, text string, literals bool) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	words := make(map[string]bool)
+	for _, w := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		words[stem(w)] = true
+	}
+	for _, t := range terms {
+		if words[t] {
+			return true
+		}
+		if p, ok := termPatterns[t]; literals && ok && p.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+var wordPattern = regexp.MustCompile(`[a-z0-9_]+`)
+
+// stopWords carry no information about which branch an example exercises.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "is": true, "are": true, "be": true,
+	"been": true, "was": true, "were": true, "it": true, "its": true, "of": true,
+	"to": true, "in": true, "on": true, "at": true, "by": true, "for": true,
+	"with": true, "and": true, "or": true, "not": true, "no": true, "than": true,
+	"that": true, "this": true, "these": true, "those": true, "has": true,
+	"have": true, "does": true, "do": true, "if": true, "when": true, "then": true,
+	"else": true, "otherwise": true, "either": true, "return": true,
+	"returns": true, "result": true, "value": true, "input": true, "inputs": true,
+	"output": true, "given": true, "any": true, "all": true, "each": true,
+	"some": true, "should": true, "must": true, "will": true, "can": true,
+	"as": true, "from": true, "into": true, "only": true, "same": true,
+	"unchanged": true, "both": true, "neither": true, "nor": true,
+	"provided": true, "present": true, "their": true,
+}
+
+// significantTerms returns the stemmed words of text that can identify a
+// branch, skipping stop words and ignored words such as input names.
+func significantTerms(text string, ignore []string) []string {
+	skip := make(map[string]bool, len(ignore))
+	for _, w := range ignore {
+		skip[stem(strings.ToLower(w))] = true
+	}
+
+	seen := make(map[string]bool)
+	var terms []string
+	for _, w := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		if stopWords[w] {
+			continue
+		}
+		w = stem(w)
+		if skip[w] || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// stem reduces simple plurals and verb forms so "numbers" matches "number"
+// and "fails" matches "fail".
+func stem(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		return w[:len(w)-1]
+	}
+	return w
+}
@@ -0,0 +1,117 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExamples(t *testing.T) {
+	examples := ParseExamples(`
+  # empty list
+  ([]) → 0
+  ("a # b", 2) -> "x"  // quoted hash kept
+  not an example
+  (7)`)
+
+	require.Len(t, examples, 3)
+	assert.Equal(t, "([])", examples[0].Input)
+	assert.Equal(t, "0", examples[0].Output)
+	assert.Equal(t, "empty list", examples[0].Comment)
+
+	assert.Equal(t, `("a # b", 2)`, examples[1].Input)
+	assert.Equal(t, `"x"`, examples[1].Output)
+	assert.Equal(t, "quoted hash kept", examples[1].Comment)
+
+	assert.Equal(t, "(7)", examples[2].Input)
+	assert.Empty(t, examples[2].Output)
+}
+
+func TestExtractBranches(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		want  []string
+	}{
+		{"if", "- if n is negative, return -1", []string{"n is negative"}},
+		{"when", "- when the list is empty: return 0", []string{"the list is empty"}},
+		{"if or", "- if input is C or D, return Z", []string{"input is c", "d"}},
+		{"otherwise", "- if total is over 100, shipping is free, otherwise charge 5",
+			[]string{"total is over 100", "otherwise: charge 5"}},
+		{"either", "- either accept the order or reject it", []string{"accept the order", "reject it"}},
+		{"optionally", "- optionally include a receipt", []string{"with include a receipt", "without include a receipt"}},
+		{"outcome first", "- return valid if no issues", []string{"no issues"}},
+		{"none", "- sort by date", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, b := range ExtractBranches(tt.rules, nil) {
+				got = append(got, b.Text)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtractBranches_Terms(t *testing.T) {
+	branches := ExtractBranches("- if items are missing, fail\n- return valid if no issues", []string{"items"})
+	require.Len(t, branches, 2)
+	assert.Equal(t, []string{"missing"}, branches[0].Terms, "input names are ignored")
+	assert.Equal(t, []string{"issue"}, branches[1].Terms)
+	assert.True(t, branches[1].Negated)
+
+	branches = ExtractBranches("- if total is over 100, shipping is free, otherwise charge 5", nil)
+	require.Len(t, branches, 2)
+	assert.Equal(t, [][]string{{"total", "over", "100"}}, branches[1].Excludes)
+	assert.Equal(t, []string{"charge", "5"}, branches[1].Outcome)
+}
+
+func TestBranch_CoveredBy(t *testing.T) {
+	ex := func(line string) Example { return ParseExamples(line)[0] }
+
+	negative := ExtractBranches("- if n is negative, return \"below\"", []string{"n"})[0]
+	assert.True(t, negative.CoveredBy(ex(`(-4) → "below"`)))
+	assert.False(t, negative.CoveredBy(ex(`(4) → "above"`)))
+	assert.True(t, negative.CoveredBy(ex("(x) → y  # negative")), "comments count")
+
+	number := ExtractBranches("- if a is not a number, fail", []string{"a"})[0]
+	assert.False(t, number.CoveredBy(ex("(2) → 2")), "negated conditions skip literal patterns")
+	assert.False(t, number.CoveredBy(ex(`("x") → Error: not a number`)), "outputs don't count")
+	assert.True(t, number.CoveredBy(ex(`("x") → Error  # not a number`)))
+
+	branches := ExtractBranches("- if destination is international, add a fee, otherwise charge 5", nil)
+	require.Len(t, branches, 2)
+	assert.False(t, branches[0].CoveredBy(ex(`({destination: "US"}) → 5`)), "every condition term must match")
+	assert.True(t, branches[0].CoveredBy(ex(`({destination: "international"}) → 9`)))
+	assert.True(t, branches[1].CoveredBy(ex(`({to: "US"}) → 7`)), "otherwise: none of the sibling terms")
+	assert.True(t, branches[1].CoveredBy(ex(`({destination: "US"}) → 5`)), "otherwise: sibling condition not met")
+	assert.False(t, branches[1].CoveredBy(ex(`({destination: "international"}) → 9`)))
+
+	shared := ExtractBranches("- if n is negative or zero, return error\n- if n is positive, return ok", []string{"n"})
+	require.Len(t, shared, 3)
+	assert.True(t, shared[0].CoveredBy(ex("(-1) → error")))
+	assert.False(t, shared[1].CoveredBy(ex("(-1) → error")), "a shared outcome doesn't cover the sibling")
+}
+
+func TestBranch_Decidable(t *testing.T) {
+	examples := ParseExamples(`
+  (cart_with_a, item_a) → { items: [item_a(qty:2)] }
+  (empty_cart, item_b) → { items: [item_b] }`)
+
+	branches := ExtractBranches("- if item already in cart, increase quantity\n- if quantity is zero, remove item", []string{"cart", "item"})
+	require.Len(t, branches, 2)
+	assert.False(t, branches[0].Decidable(examples), "'already' appears in no example")
+	assert.True(t, branches[1].Decidable(examples), "'zero' has a literal pattern")
+
+	mentioned := ExtractBranches("- if the cart is empty, fail", nil)[0]
+	assert.True(t, mentioned.Decidable(examples), "'cart' appears in the inputs")
+}
+
+func TestIdentifiable(t *testing.T) {
+	assert.True(t, identifiable(ExtractBranches("- if n is negative, fail\n- otherwise return n", []string{"n"})))
+	assert.False(t, identifiable(ExtractBranches("- if a, return a\n- if b, return b", []string{"a", "b"})), "no terms")
+	assert.False(t, identifiable(ExtractBranches("- if list is empty, return 0\n- when the list is empty, log it", nil)), "same terms")
+	assert.False(t, identifiable(nil))
+}
@@ -0,0 +1,380 @@
+package checks
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+)
+
+// TypeKind is the kind of a DATA field type.
+type TypeKind string
+
+// Field type kinds.
+const (
+	KindString  TypeKind = "string"
+	KindNumber  TypeKind = "number"
+	KindInteger TypeKind = "integer"
+	KindBoolean TypeKind = "boolean"
+	KindNull    TypeKind = "null"
+	KindAny     TypeKind = "any"
+	KindEnum    TypeKind = "enum"  // Values lists the allowed values
+	KindList    TypeKind = "list"  // Elem is the element type
+	KindRef     TypeKind = "ref"   // Name is the DATA type referenced
+	KindUnion   TypeKind = "union" // Alternatives lists the member types
+	KindUnknown TypeKind = "unknown"
+)
+
+// Type is a parsed DATA field type such as "list of Finding",
+// "critical | warning | info" or "User | null".
+type Type struct {
+	Kind         TypeKind
+	Name         string // DATA type name, for KindRef
+	Elem         *Type  // for KindList
+	Values       []string
+	Alternatives []Type
+}
+
+// String returns the type as written in a spec.
+func (t Type) String() string {
+	switch t.Kind {
+	case KindRef:
+		return t.Name
+	case KindList:
+		return "list of " + t.Elem.String()
+	case KindEnum:
+		return strings.Join(t.Values, " | ")
+	case KindUnion:
+		parts := make([]string, len(t.Alternatives))
+		for i, alt := range t.Alternatives {
+			parts[i] = alt.String()
+		}
+		return strings.Join(parts, " | ")
+	}
+	return string(t.Kind)
+}
+
+// References returns the DATA type names t refers to, following list
+// elements and union alternatives, in order.
+func (t Type) References() []string {
+	switch t.Kind {
+	case KindRef:
+		return []string{t.Name}
+	case KindList:
+		return t.Elem.References()
+	case KindUnion:
+		var refs []string
+		for _, alt := range t.Alternatives {
+			refs = append(refs, alt.References()...)
+		}
+		return refs
+	}
+	return nil
+}
+
+// Resolve returns t with plural list element names, as in "list of Items",
+// replaced by the DATA type they name. The name as written wins; it is
+// singularized only when it is undefined and its singular is defined, so
+// "list of Status" keeps Status.
+func (t Type) Resolve(types map[string]*DataType) Type {
+	switch t.Kind {
+	case KindList:
+		elem := t.Elem.Resolve(types)
+		if elem.Kind == KindRef && types[elem.Name] == nil {
+			for _, s := range singulars(elem.Name) {
+				if types[s] != nil {
+					elem.Name = s
+					break
+				}
+			}
+		}
+		t.Elem = &elem
+	case KindUnion:
+		alts := make([]Type, len(t.Alternatives))
+		for i, alt := range t.Alternatives {
+			alts[i] = alt.Resolve(types)
+		}
+		t.Alternatives = alts
+	}
+	return t
+}
+
+// Presence says whether a DATA field must appear in an output.
+type Presence string
+
+// Field presence annotations.
+const (
+	Required    Presence = "required" // the default
+	Optional    Presence = "optional"
+	Conditional Presence = "conditional" // "present when ..."
+	NotAllowed  Presence = "not allowed"
+)
+
+// Field is one "name: type, annotations" line of a DATA block.
+type Field struct {
+	Name     string
+	Type     Type
+	Presence Presence
+}
+
+// DataType is a parsed DATA block.
+type DataType struct {
+	Name   string
+	Fields []Field
+}
+
+// Field returns the named field, or nil.
+func (d *DataType) Field(name string) *Field {
+	for i := range d.Fields {
+		if d.Fields[i].Name == name {
+			return &d.Fields[i]
+		}
+	}
+	return nil
+}
+
+// ParseDataTypes parses the spec's DATA blocks, keyed by type name, with
+// field types resolved against each other. A later block with the same
+// name replaces an earlier one.
+func ParseDataTypes(blocks []parser.Landmark) map[string]*DataType {
+	types := make(map[string]*DataType, len(blocks))
+	for _, block := range blocks {
+		if dt := ParseDataType(block.Content); dt != nil {
+			types[dt.Name] = dt
+		}
+	}
+	for _, dt := range types {
+		for i := range dt.Fields {
+			dt.Fields[i].Type = dt.Fields[i].Type.Resolve(types)
+		}
+	}
+	return types
+}
+
+var fieldPattern = regexp.MustCompile(`^([A-Za-z_][\w-]*)\s*:\s*(.*)$`)
+
+// ParseDataType parses DATA block content: the type name on the first line,
+// then one "name: type, annotations" line per field.
+func ParseDataType(content string) *DataType {
+	lines := strings.Split(content, "\n")
+	name := strings.TrimSpace(lines[0])
+	if name == "" {
+		return nil
+	}
+
+	dt := &DataType{Name: name}
+	for _, line := range lines[1:] {
+		m := fieldPattern.FindStringSubmatch(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "-")))
+		if m == nil {
+			continue
+		}
+		typ, annotations := splitTopLevel(m[2], ',')
+		dt.Fields = append(dt.Fields, Field{
+			Name:     m[1],
+			Type:     ParseType(typ),
+			Presence: parsePresence(annotations),
+		})
+	}
+	return dt
+}
+
+// splitTopLevel splits s at the first sep outside quotes.
+func splitTopLevel(s string, sep byte) (string, string) {
+	inQuote := byte(0)
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case inQuote != 0:
+			if ch == inQuote {
+				inQuote = 0
+			}
+		case ch == '"' || ch == '\'':
+			inQuote = ch
+		case ch == sep:
+			return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+		}
+	}
+	return strings.TrimSpace(s), ""
+}
+
+var (
+	presentWhen  = regexp.MustCompile(`(?i)\b(present|required|only)\s+(when|if)\b`)
+	optionalWord = regexp.MustCompile(`\boptional\b`)
+)
+
+func parsePresence(annotations string) Presence {
+	lower := strings.ToLower(annotations)
+	switch {
+	case strings.Contains(lower, "not allowed"):
+		return NotAllowed
+	case presentWhen.MatchString(lower):
+		return Conditional
+	case optionalWord.MatchString(lower):
+		return Optional
+	}
+	return Required
+}
+
+// primitiveTypes maps the words that name primitive DATA field types,
+// including common formats that are strings in example outputs.
+var primitiveTypes = map[string]TypeKind{
+	"string": KindString, "text": KindString, "str": KindString,
+	"uuid": KindString, "email": KindString, "url": KindString, "uri": KindString,
+	"date": KindString, "datetime": KindString, "time": KindString,
+	"iso8601": KindString, "timestamp": KindString,
+	"number": KindNumber, "float": KindNumber, "double": KindNumber, "decimal": KindNumber,
+	"integer": KindInteger, "int": KindInteger,
+	"boolean": KindBoolean, "bool": KindBoolean,
+	"null": KindNull, "none": KindNull, "nil": KindNull,
+	"any": KindAny, "object": KindAny, "map": KindAny, "dict": KindAny,
+}
+
+var (
+	listPattern       = regexp.MustCompile(`(?i)^(list|array|set|sequence)\s+of\s+(.+)$`)
+	typeNamePattern   = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+	enumValuePattern  = regexp.MustCompile(`^("[^"]*"|'[^']*'|-?\d+(\.\d+)?|[\w.-]+)$`)
+	typeQualifierWord = regexp.MustCompile(`(?i)^(positive|negative|non-negative|nonnegative|unsigned|signed|iso8601|iso|utc|unix|unique)$`)
+)
+
+// ParseType parses a DATA field type. Text it doesn't recognize, such as a
+// prose description, parses as KindUnknown and matches any value.
+func ParseType(s string) Type {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Type{Kind: KindUnknown}
+	}
+
+	if parts := strings.Split(s, "|"); len(parts) > 1 {
+		var alts []Type
+		var values []string
+		literals := true
+		for _, part := range parts {
+			part = strings.TrimSpace(part)
+			alt := ParseType(part)
+			alts = append(alts, alt)
+			if alt.Kind != KindUnknown || !enumValuePattern.MatchString(part) {
+				literals = false
+			}
+			values = append(values, strings.Trim(part, `"'`))
+		}
+		if literals {
+			return Type{Kind: KindEnum, Values: values}
+		}
+		return Type{Kind: KindUnion, Alternatives: alts}
+	}
+
+	if m := listPattern.FindStringSubmatch(s); m != nil {
+		// "list of strings"; a plural DATA name waits for Resolve
+		elem := ParseType(m[2])
+		if p := ParseType(singular(m[2])); elem.Kind == KindUnknown || (elem.Kind == KindRef && p.Kind != KindRef && p.Kind != KindUnknown) {
+			elem = p
+		}
+		return Type{Kind: KindList, Elem: &elem}
+	}
+	if strings.HasSuffix(s, "[]") {
+		elem := ParseType(strings.TrimSuffix(s, "[]"))
+		return Type{Kind: KindList, Elem: &elem}
+	}
+
+	if typeNamePattern.MatchString(s) {
+		if kind, ok := primitiveTypes[strings.ToLower(s)]; ok {
+			return Type{Kind: kind}
+		}
+		return Type{Kind: KindRef, Name: s}
+	}
+
+	// "positive integer", "ISO8601 datetime": qualifiers before a primitive
+	words := strings.Fields(s)
+	last := strings.ToLower(words[len(words)-1])
+	if kind, ok := primitiveTypes[last]; ok {
+		for _, w := range words[:len(words)-1] {
+			if !typeQualifierWord.MatchString(w) {
+				return Type{Kind: KindUnknown}
+			}
+		}
+		return Type{Kind: kind}
+	}
+	return Type{Kind: KindUnknown}
+}
+
+// ParseInput splits a FUNCTION input such as "items: list of Item" into its
+// name and declared type. typed is false for an input without a type.
+func ParseInput(input string) (name string, t Type, typed bool) {
+	name, typ, typed := strings.Cut(input, ":")
+	name = strings.TrimSpace(name)
+	if !typed {
+		return name, Type{Kind: KindUnknown}, false
+	}
+	return name, ParseType(typ), true
+}
+
+// RecursiveTypes returns the names of the DATA types that contain
+// themselves, directly or through other types, sorted.
+func RecursiveTypes(types map[string]*DataType) []string {
+	var recursive []string
+	for name := range types {
+		if reaches(name, name, types, make(map[string]bool)) {
+			recursive = append(recursive, name)
+		}
+	}
+	sort.Strings(recursive)
+	return recursive
+}
+
+// reaches reports whether a field of the type from refers to target,
+// directly or through the types its fields refer to.
+func reaches(from, target string, types map[string]*DataType, seen map[string]bool) bool {
+	dt := types[from]
+	if dt == nil || seen[from] {
+		return false
+	}
+	seen[from] = true
+	for _, f := range dt.Fields {
+		for _, ref := range f.Type.References() {
+			if ref == target || reaches(ref, target, types, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// singular turns "strings" into "string" and "Findings" into "Finding" for
+// "list of" element types.
+func singular(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, " ") || len(s) < 4 || !strings.HasSuffix(s, "s") || strings.HasSuffix(s, "ss") {
+		return s
+	}
+	return strings.TrimSuffix(s, "s")
+}
+
+// singulars returns the names a plural type name may stand for, e.g.
+// "Address" for "Addresses" and "Policy" for "Policies".
+func singulars(s string) []string {
+	var names []string
+	if strings.HasSuffix(s, "ies") {
+		names = append(names, strings.TrimSuffix(s, "ies")+"y")
+	}
+	if strings.HasSuffix(s, "es") {
+		names = append(names, strings.TrimSuffix(s, "es"))
+	}
+	if strings.HasSuffix(s, "s") {
+		names = append(names, strings.TrimSuffix(s, "s"))
+	}
+	return names
+}
+
+// returnTypeRef returns the DATA type a function return type names, such as
+// "Response" or "list of PolicyRule", resolved against types, and whether
+// the return type is one.
+func returnTypeRef(returnType string, types map[string]*DataType) (Type, bool) {
+	t := ParseType(returnType).Resolve(types)
+	switch {
+	case t.Kind == KindRef:
+		return t, true
+	case t.Kind == KindList && t.Elem.Kind == KindRef:
+		return t, true
+	}
+	return t, false
+}
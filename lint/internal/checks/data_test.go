@@ -0,0 +1,108 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+)
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		kind TypeKind
+	}{
+		{"string", "string", KindString},
+		{"positive integer", "integer", KindInteger},
+		{"ISO8601 datetime", "string", KindString},
+		{"User", "User", KindRef},
+		{"list of Findings", "list of Findings", KindList},
+		{"list of strings", "list of string", KindList},
+		{"Finding[]", "list of Finding", KindList},
+		{"critical | warning | info", "critical | warning | info", KindEnum},
+		{"User | null", "User | null", KindUnion},
+		{"whatever the caller sent", "unknown", KindUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := ParseType(tt.in)
+			assert.Equal(t, tt.kind, got.Kind)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestParseDataType_Presence(t *testing.T) {
+	dt := ParseDataType(`Response
+  id: string
+  note: string, optional
+  error: string, present when the request fails
+  password: string, not allowed
+  - tags: list of string`)
+
+	require.NotNil(t, dt)
+	assert.Equal(t, "Response", dt.Name)
+	require.Len(t, dt.Fields, 5)
+	assert.Equal(t, Required, dt.Field("id").Presence)
+	assert.Equal(t, Optional, dt.Field("note").Presence)
+	assert.Equal(t, Conditional, dt.Field("error").Presence)
+	assert.Equal(t, NotAllowed, dt.Field("password").Presence)
+	assert.Equal(t, KindList, dt.Field("tags").Type.Kind)
+	assert.Nil(t, dt.Field("missing"))
+}
+
+func TestReturnTypeRef(t *testing.T) {
+	_, ok := returnTypeRef("Response", nil)
+	assert.True(t, ok)
+	_, ok = returnTypeRef("list of PolicyRule", nil)
+	assert.True(t, ok)
+	_, ok = returnTypeRef("boolean", nil)
+	assert.False(t, ok)
+	_, ok = returnTypeRef("filtered list", nil)
+	assert.False(t, ok)
+}
+
+func TestType_References(t *testing.T) {
+	assert.Equal(t, []string{"Items"}, ParseType("list of Items").References())
+	assert.Equal(t, []string{"User", "Guest"}, ParseType("User | Guest | null").References())
+	assert.Empty(t, ParseType("open | closed").References(), "enum values are not types")
+	assert.Empty(t, ParseType("positive integer").References())
+}
+
+func TestType_Resolve(t *testing.T) {
+	types := ParseDataTypes([]parser.Landmark{
+		{Content: "Status\n  code: string"},
+		{Content: "Address\n  street: string"},
+		{Content: "Order\n  history: list of Status\n  stops: list of Addresses"},
+	})
+
+	assert.Equal(t, "list of Status", ParseType("list of Status").Resolve(types).String())
+	assert.Equal(t, "list of Address", ParseType("list of Addresses").Resolve(types).String())
+	assert.Equal(t, "list of Address | null", ParseType("list of Addresses | null").Resolve(types).String())
+	assert.Equal(t, "list of Widgets", ParseType("list of Widgets").Resolve(types).String(), "undefined either way")
+	assert.Equal(t, []string{"Status"}, types["Order"].Field("history").Type.References())
+	assert.Equal(t, []string{"Address"}, types["Order"].Field("stops").Type.References())
+}
+
+func TestParseInput(t *testing.T) {
+	name, typ, typed := ParseInput("items: list of Item")
+	assert.Equal(t, "items", name)
+	assert.True(t, typed)
+	assert.Equal(t, []string{"Item"}, typ.References())
+
+	name, _, typed = ParseInput("cart")
+	assert.Equal(t, "cart", name)
+	assert.False(t, typed)
+}
+
+func TestRecursiveTypes(t *testing.T) {
+	types := ParseDataTypes([]parser.Landmark{
+		{Content: "Node\n  children: list of Node"},
+		{Content: "Folder\n  files: list of File"},
+		{Content: "File\n  parent: Folder | null"},
+		{Content: "Leaf\n  value: string"},
+	})
+	assert.Equal(t, []string{"File", "Folder", "Node"}, RecursiveTypes(types))
+}
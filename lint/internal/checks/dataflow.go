@@ -0,0 +1,467 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// DataFlowConfig holds settings for the data-flow graph checks.
+type DataFlowConfig struct {
+	// External lists key prefixes produced or consumed outside the specs,
+	// such as "env." or "Database.". Matching keys are never dangling or
+	// unreachable.
+	External []string
+}
+
+// DataFlowChecker checks that the READS, WRITES and TRIGGERS keys of a
+// spec's functions connect.
+type DataFlowChecker struct {
+	config DataFlowConfig
+}
+
+// NewDataFlowChecker creates a DataFlowChecker with no external prefixes.
+func NewDataFlowChecker() *DataFlowChecker {
+	return NewDataFlowCheckerWithConfig(DataFlowConfig{})
+}
+
+// NewDataFlowCheckerWithConfig creates a DataFlowChecker with custom config.
+func NewDataFlowCheckerWithConfig(config DataFlowConfig) *DataFlowChecker {
+	return &DataFlowChecker{config: config}
+}
+
+// Check builds the data-flow graph of one spec and reports its findings.
+func (c *DataFlowChecker) Check(spec *parser.ParsedSpec, r *result.LintResult) {
+	c.CheckContext(context.Background(), spec, r)
+}
+
+// CheckContext is Check with a context; see FlowGraph.CheckContext.
+func (c *DataFlowChecker) CheckContext(ctx context.Context, spec *parser.ParsedSpec, r *result.LintResult) {
+	g := NewFlowGraph()
+	g.Add("", spec)
+	for _, f := range g.CheckContext(ctx, c.config) {
+		f.Report(r)
+	}
+}
+
+// dataFlowKeyPattern matches a dotted-path key such as artifacts.registry_path.
+var dataFlowKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// ParseDataFlowKey splits a READS or WRITES entry such as
+// "artifacts.registry_path: path to the registry" into its key and
+// description. It reports false for entries whose key is not a dotted path,
+// such as the free-form SharedMemory.artifacts["registry_path"].
+func ParseDataFlowKey(entry string) (key, description string, ok bool) {
+	key, description, _ = strings.Cut(entry, ":")
+	key, description = strings.TrimSpace(key), strings.TrimSpace(description)
+	if !dataFlowKeyPattern.MatchString(key) {
+		return entry, "", false
+	}
+	return key, description, true
+}
+
+// triggerPattern matches "key exists", "key == value" and "key != value".
+var triggerPattern = regexp.MustCompile(`^([A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)\s+(exists\b|==|!=)\s*(.*)$`)
+
+// ParseTrigger splits a TRIGGERS entry such as "status.compilation != success"
+// into its key and condition. It reports false for free-form entries.
+func ParseTrigger(entry string) (key, condition string, ok bool) {
+	m := triggerPattern.FindStringSubmatch(strings.TrimSpace(entry))
+	if m == nil {
+		return entry, "", false
+	}
+	return m[1], strings.TrimSpace(m[2] + " " + m[3]), true
+}
+
+// FlowNode holds one function's parseable data-flow keys.
+type FlowNode struct {
+	File     string // spec file, empty for a single spec
+	Function string
+	Reads    []string
+	Writes   []string
+	Triggers []string
+}
+
+// FlowGraph is the data-flow graph of one or more specs: an edge runs from
+// each function that writes a key to each function that reads it or
+// triggers on it.
+type FlowGraph struct {
+	Nodes []FlowNode
+}
+
+// NewFlowGraph creates an empty graph.
+func NewFlowGraph() *FlowGraph {
+	return &FlowGraph{}
+}
+
+// Add adds the functions of a spec read from file to the graph.
+func (g *FlowGraph) Add(file string, spec *parser.ParsedSpec) {
+	for _, fn := range spec.Functions {
+		node := FlowNode{File: file, Function: fn.Name}
+		for _, entry := range landmarkItems(fn, parser.LandmarkREADS) {
+			if key, _, ok := ParseDataFlowKey(entry); ok {
+				node.Reads = append(node.Reads, key)
+			}
+		}
+		for _, entry := range landmarkItems(fn, parser.LandmarkWRITES) {
+			if key, _, ok := ParseDataFlowKey(entry); ok {
+				node.Writes = append(node.Writes, key)
+			}
+		}
+		for _, entry := range landmarkItems(fn, parser.LandmarkTRIGGERS) {
+			if key, _, ok := ParseTrigger(entry); ok {
+				node.Triggers = append(node.Triggers, key)
+			}
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+}
+
+// landmarkItems returns the "- item" lines of a function landmark.
+func landmarkItems(fn parser.FunctionBlock, name string) []string {
+	lm := fn.GetLandmark(name)
+	if lm == nil {
+		return nil
+	}
+	var items []string
+	for _, line := range strings.Split(lm.Content, "\n") {
+		if item, ok := strings.CutPrefix(strings.TrimSpace(line), "-"); ok {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// FlowFinding is a data-flow issue attributed to the file and function
+// whose declaration it concerns.
+type FlowFinding struct {
+	Code     string
+	Message  string
+	File     string
+	Function string
+	Landmark string // READS, WRITES or TRIGGERS
+	Key      string // the dangling or unreachable key, empty for cycles
+	// Related are the cycle's other functions in File.
+	Related []string
+}
+
+// Location returns the issue location, e.g. "FUNCTION compile READS".
+func (f FlowFinding) Location() string {
+	return formatFunctionLocation(f.Function) + " " + f.Landmark
+}
+
+// Report adds the finding to r.
+func (f FlowFinding) Report(r *result.LintResult) {
+	if strings.HasPrefix(f.Code, "W") {
+		r.AddWarning(f.Code, f.Message, f.Location())
+		r.Warnings[len(r.Warnings)-1].Related = f.Related
+	} else {
+		r.AddError(f.Code, f.Message, f.Location())
+		r.Errors[len(r.Errors)-1].Related = f.Related
+	}
+}
+
+// Check reports keys that don't connect and functions that activate each
+// other in a loop.
+func (g *FlowGraph) Check(config DataFlowConfig) []FlowFinding {
+	return g.CheckContext(context.Background(), config)
+}
+
+// CheckContext is Check with a context. The cycle search stops once ctx is
+// done, so the findings are incomplete whenever ctx.Err() is non-nil.
+func (g *FlowGraph) CheckContext(ctx context.Context, config DataFlowConfig) []FlowFinding {
+	return append(g.checkKeys(config), g.checkCycles(ctx)...)
+}
+
+// checkKeys reports keys that don't connect. It only runs when at least two
+// functions declare parseable keys, so a standalone function is unaffected.
+// Error E100: dangling read, a READS key no function writes
+// Error E101: dangling trigger, a TRIGGERS key no function writes
+// Warning W100: unreachable write, a WRITES key no function reads or triggers on
+func (g *FlowGraph) checkKeys(config DataFlowConfig) []FlowFinding {
+	participants := 0
+	written := make(map[string]bool)
+	consumed := make(map[string]bool)
+	for _, n := range g.Nodes {
+		if len(n.Reads)+len(n.Writes)+len(n.Triggers) > 0 {
+			participants++
+		}
+		for _, k := range n.Writes {
+			written[k] = true
+		}
+		for _, k := range append(append([]string{}, n.Reads...), n.Triggers...) {
+			consumed[k] = true
+		}
+	}
+	if participants < 2 {
+		return nil
+	}
+
+	var findings []FlowFinding
+	add := func(n FlowNode, code, landmark, key, format string) {
+		if IsExternalKey(key, config.External) {
+			return
+		}
+		findings = append(findings, FlowFinding{
+			Code:     code,
+			Message:  fmt.Sprintf(format, key),
+			File:     n.File,
+			Function: n.Function,
+			Landmark: landmark,
+			Key:      key,
+		})
+	}
+	for _, n := range g.Nodes {
+		for _, k := range unique(n.Reads) {
+			if !written[k] {
+				add(n, "E100", parser.LandmarkREADS, k, "dangling read: '%s' not produced by any function")
+			}
+		}
+		for _, k := range unique(n.Triggers) {
+			if !written[k] {
+				add(n, "E101", parser.LandmarkTRIGGERS, k, "dangling trigger: '%s' not produced by any function")
+			}
+		}
+		for _, k := range unique(n.Writes) {
+			if !consumed[k] {
+				add(n, "W100", parser.LandmarkWRITES, k, "unreachable write: '%s' not consumed by any function")
+			}
+		}
+	}
+	return findings
+}
+
+// maxCycles caps the cycles reported for one graph; densely connected
+// functions can form exponentially many.
+const maxCycles = 20
+
+// activation is an edge of the activation graph: writing key activates the
+// function at index to.
+type activation struct {
+	to  int
+	key string
+}
+
+// activations returns, for each node, the nodes its WRITES trigger, in node
+// order. Trigger conditions are not evaluated, so a write activates every
+// function that triggers on its key.
+func (g *FlowGraph) activations() [][]activation {
+	triggeredBy := make(map[string][]int)
+	for i, n := range g.Nodes {
+		for _, k := range unique(n.Triggers) {
+			triggeredBy[k] = append(triggeredBy[k], i)
+		}
+	}
+
+	edges := make([][]activation, len(g.Nodes))
+	for i, n := range g.Nodes {
+		seen := make(map[int]bool)
+		for _, k := range unique(n.Writes) {
+			for _, j := range triggeredBy[k] {
+				if !seen[j] {
+					seen[j] = true
+					edges[i] = append(edges[i], activation{to: j, key: k})
+				}
+			}
+		}
+	}
+	return edges
+}
+
+// checkCycles reports loops in the activation graph, where a function's
+// WRITES fire another function's TRIGGERS. Each cycle is reported once, at
+// the TRIGGERS of its earliest function, with the path starting there; an
+// intentional loop is marked with an ignore directive in that landmark.
+// Error E102: trigger cycle between two or more functions
+// Error E103: function triggers on a key it writes
+func (g *FlowGraph) checkCycles(ctx context.Context) []FlowFinding {
+	edges := g.activations()
+	var findings []FlowFinding
+
+	for i, n := range g.Nodes {
+		for _, e := range edges[i] {
+			if e.to == i {
+				findings = append(findings, FlowFinding{
+					Code:     "E103",
+					Message:  fmt.Sprintf("self-activation: '%s' triggers on '%s', which it writes", n.Function, e.key),
+					File:     n.File,
+					Function: n.Function,
+					Landmark: parser.LandmarkTRIGGERS,
+				})
+			}
+		}
+	}
+
+	// Enumerate elementary cycles from each start node through later nodes
+	// only, so every cycle is found once, from its earliest function. The
+	// search stays inside the strongly connected component of the start and
+	// blocks nodes that can't reach it (Johnson's algorithm), so an acyclic
+	// graph costs one pass per node instead of one per path.
+	var path []activation
+	blocked := make([]bool, len(g.Nodes))
+	blockedBy := make([]map[int]bool, len(g.Nodes))
+	var unblock func(v int)
+	unblock = func(v int) {
+		blocked[v] = false
+		for w := range blockedBy[v] {
+			delete(blockedBy[v], w)
+			if blocked[w] {
+				unblock(w)
+			}
+		}
+	}
+
+	cycles := 0
+	var visit func(start, at int, in []bool) bool
+	visit = func(start, at int, in []bool) bool {
+		found := false
+		blocked[at] = true
+		for _, e := range edges[at] {
+			if cycles >= maxCycles || ctx.Err() != nil {
+				return true
+			}
+			switch {
+			case e.to == at || !in[e.to]:
+			case e.to == start:
+				findings = append(findings, g.cycleFinding(start, append(path, e)))
+				cycles++
+				found = true
+			case !blocked[e.to]:
+				path = append(path, e)
+				if visit(start, e.to, in) {
+					found = true
+				}
+				path = path[:len(path)-1]
+			}
+		}
+		if found {
+			unblock(at)
+		} else {
+			for _, e := range edges[at] {
+				if in[e.to] {
+					blockedBy[e.to][at] = true
+				}
+			}
+		}
+		return found
+	}
+	succ := make([][]int, len(g.Nodes))
+	pred := make([][]int, len(g.Nodes))
+	for i := range edges {
+		for _, e := range edges[i] {
+			succ[i] = append(succ[i], e.to)
+			pred[e.to] = append(pred[e.to], i)
+		}
+	}
+	for start := 0; start < len(g.Nodes) && cycles < maxCycles && ctx.Err() == nil; start++ {
+		in := component(succ, pred, start)
+		if in == nil {
+			continue
+		}
+		for v := range g.Nodes {
+			blocked[v] = false
+			blockedBy[v] = make(map[int]bool)
+		}
+		visit(start, start, in)
+	}
+	return findings
+}
+
+// component returns the members of the strongly connected component of
+// start among the nodes from start on, or nil when start is on no cycle
+// through them. succ and pred list each node's successors and predecessors.
+func component(succ, pred [][]int, start int) []bool {
+	forward, backward := reach(succ, start), reach(pred, start)
+	in := make([]bool, len(succ))
+	size := 0
+	for v := start; v < len(succ); v++ {
+		if forward[v] && backward[v] {
+			in[v] = true
+			size++
+		}
+	}
+	if size < 2 {
+		return nil
+	}
+	return in
+}
+
+// reach marks the nodes from start on that start reaches along adj.
+func reach(adj [][]int, start int) []bool {
+	seen := make([]bool, len(adj))
+	seen[start] = true
+	stack := []int{start}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, w := range adj[v] {
+			if w >= start && !seen[w] {
+				seen[w] = true
+				stack = append(stack, w)
+			}
+		}
+	}
+	return seen
+}
+
+// cycleFinding describes the cycle from node start along path, e.g.
+// "trigger cycle: compile → deploy → compile (via status.compilation, status.deployed)".
+func (g *FlowGraph) cycleFinding(start int, path []activation) FlowFinding {
+	n := g.Nodes[start]
+	names := []string{n.Function}
+	keys := make([]string, 0, len(path))
+	var related []string
+	for _, e := range path {
+		names = append(names, g.nodeLabel(e.to, n.File))
+		keys = append(keys, e.key)
+		if e.to != start && g.Nodes[e.to].File == n.File {
+			related = append(related, g.Nodes[e.to].Function)
+		}
+	}
+	return FlowFinding{
+		Code:     "E102",
+		Message:  fmt.Sprintf("trigger cycle: %s (via %s)", strings.Join(names, " → "), strings.Join(keys, ", ")),
+		File:     n.File,
+		Function: n.Function,
+		Landmark: parser.LandmarkTRIGGERS,
+		Related:  related,
+	}
+}
+
+// nodeLabel names a node, adding its file when it differs from file.
+func (g *FlowGraph) nodeLabel(i int, file string) string {
+	n := g.Nodes[i]
+	if n.File != file {
+		return fmt.Sprintf("%s (%s)", n.Function, n.File)
+	}
+	return n.Function
+}
+
+// IsExternalKey reports whether key starts with one of the external prefixes.
+func IsExternalKey(key string, external []string) bool {
+	for _, prefix := range external {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// unique returns keys without repeats, in order.
+func unique(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	var out []string
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+	return out
+}
@@ -0,0 +1,234 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func TestParseDataFlowKey(t *testing.T) {
+	tests := []struct {
+		entry, key, description string
+		ok                      bool
+	}{
+		{"artifacts.registry_path: path to registry", "artifacts.registry_path", "path to registry", true},
+		{"status.compilation: success | failure", "status.compilation", "success | failure", true},
+		{"artifacts.compiled_output", "artifacts.compiled_output", "", true},
+		{`SharedMemory.artifacts["registry"]`, `SharedMemory.artifacts["registry"]`, "", false},
+		{"filesystem at {path}", "filesystem at {path}", "", false},
+	}
+	for _, tt := range tests {
+		key, description, ok := ParseDataFlowKey(tt.entry)
+		assert.Equal(t, tt.ok, ok, tt.entry)
+		assert.Equal(t, tt.key, key, tt.entry)
+		assert.Equal(t, tt.description, description, tt.entry)
+	}
+}
+
+func TestParseTrigger(t *testing.T) {
+	key, condition, ok := ParseTrigger("status.compilation != success")
+	require.True(t, ok)
+	assert.Equal(t, "status.compilation", key)
+	assert.Equal(t, "!= success", condition)
+
+	key, condition, ok = ParseTrigger("artifacts.registry_path exists")
+	require.True(t, ok)
+	assert.Equal(t, "artifacts.registry_path", key)
+	assert.Equal(t, "exists", condition)
+
+	_, _, ok = ParseTrigger(`SharedMemory.status["compilation"] != success`)
+	assert.False(t, ok)
+}
+
+const flowSpec = `FUNCTION: compile(registry) → artifacts
+
+READS:
+  - artifacts.registry_path: path to the policy registry
+  - env.build_flags
+
+WRITES:
+  - artifacts.compiled_output: compiled agents
+  - status.compilation: success | failure
+
+FUNCTION: deploy(artifacts) → report
+
+READS:
+  - artifacts.compiled_output
+
+WRITES:
+  - status.deployed
+
+TRIGGERS:
+  - status.compilation == success
+  - status.tests_passed exists
+`
+
+func checkFlow(t *testing.T, spec string, config DataFlowConfig) *result.LintResult {
+	t.Helper()
+	r := result.NewLintResult("test.md")
+	NewDataFlowCheckerWithConfig(config).Check(parser.NewParser().Parse(spec), r)
+	return r
+}
+
+func TestDataFlowChecker_Findings(t *testing.T) {
+	r := checkFlow(t, flowSpec, DataFlowConfig{})
+
+	var got []string
+	for _, e := range r.Issues() {
+		got = append(got, e.Code+" ["+e.Location+"] "+e.Message)
+	}
+	assert.Equal(t, []string{
+		"E100 [FUNCTION compile READS] dangling read: 'artifacts.registry_path' not produced by any function",
+		"E100 [FUNCTION compile READS] dangling read: 'env.build_flags' not produced by any function",
+		"E101 [FUNCTION deploy TRIGGERS] dangling trigger: 'status.tests_passed' not produced by any function",
+		"W100 [FUNCTION deploy WRITES] unreachable write: 'status.deployed' not consumed by any function",
+	}, got)
+}
+
+func TestDataFlowChecker_ExternalPrefixes(t *testing.T) {
+	r := checkFlow(t, flowSpec, DataFlowConfig{External: []string{"env.", "artifacts.registry", "status.deployed"}})
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E101", r.Errors[0].Code)
+	assert.Empty(t, r.Warnings)
+}
+
+func TestDataFlowChecker_SingleParticipantSkipped(t *testing.T) {
+	r := checkFlow(t, `FUNCTION: login(creds) → session
+
+READS:
+  - Database.users
+
+FUNCTION: logout(session) → ok
+
+READS:
+  - SharedMemory.sessions["active"]
+`, DataFlowConfig{})
+
+	assert.Empty(t, r.Issues(), "only one function has parseable keys")
+}
+
+const cycleSpec = `FUNCTION: plan(goal) → plan
+
+WRITES:
+  - status.planned: done
+
+TRIGGERS:
+  - status.reviewed == rejected
+
+FUNCTION: review(plan) → verdict
+
+WRITES:
+  - status.reviewed: approved | rejected
+
+TRIGGERS:
+  - status.planned exists
+
+FUNCTION: poll(queue) → job
+
+WRITES:
+  - queue.next
+
+TRIGGERS:
+  - queue.next exists
+`
+
+func TestDataFlowChecker_TriggerCycles(t *testing.T) {
+	r := checkFlow(t, cycleSpec, DataFlowConfig{})
+
+	var got []string
+	for _, e := range r.Errors {
+		got = append(got, e.Code+" ["+e.Location+"] "+e.Message)
+	}
+	assert.Equal(t, []string{
+		"E103 [FUNCTION poll TRIGGERS] self-activation: 'poll' triggers on 'queue.next', which it writes",
+		"E102 [FUNCTION plan TRIGGERS] trigger cycle: plan → review → plan (via status.planned, status.reviewed)",
+	}, got)
+}
+
+func TestDataFlowChecker_CycleReportedOnce(t *testing.T) {
+	// a → b → c → a and a → c → a share nodes but are distinct cycles
+	r := checkFlow(t, `FUNCTION: a(x) → x
+
+WRITES:
+  - k.a
+
+TRIGGERS:
+  - k.c exists
+
+FUNCTION: b(x) → x
+
+WRITES:
+  - k.b
+
+TRIGGERS:
+  - k.a exists
+
+FUNCTION: c(x) → x
+
+WRITES:
+  - k.c
+
+TRIGGERS:
+  - k.b exists
+  - k.a exists
+`, DataFlowConfig{})
+
+	var got []string
+	for _, e := range r.Errors {
+		got = append(got, fmt.Sprintf("%s %v", e.Message, e.Related))
+	}
+	assert.ElementsMatch(t, []string{
+		"trigger cycle: a → b → c → a (via k.a, k.b, k.c) [b c]",
+		"trigger cycle: a → c → a (via k.a, k.c) [c]",
+	}, got)
+}
+
+func TestFlowGraph_CycleAcrossFiles(t *testing.T) {
+	p := parser.NewParser()
+	g := NewFlowGraph()
+	g.Add("plan.md", p.Parse("FUNCTION: plan(goal) → plan\n\nWRITES:\n  - status.planned\n\nTRIGGERS:\n  - status.reviewed exists\n"))
+	g.Add("review.md", p.Parse("FUNCTION: review(plan) → verdict\n\nWRITES:\n  - status.reviewed\n\nTRIGGERS:\n  - status.planned exists\n"))
+
+	findings := g.Check(DataFlowConfig{})
+	require.Len(t, findings, 1)
+	assert.Equal(t, "plan.md", findings[0].File)
+	assert.Empty(t, findings[0].Related, "review is in another file")
+	assert.Equal(t, "trigger cycle: plan → review (review.md) → plan (via status.planned, status.reviewed)", findings[0].Message)
+}
+
+func TestFlowGraph_LargeAcyclicGraph(t *testing.T) {
+	// each function triggers on every earlier function's write: no cycles,
+	// but 2^(n-2) paths from the first function to the last
+	g := NewFlowGraph()
+	for i := 0; i < 60; i++ {
+		node := FlowNode{Function: fmt.Sprintf("f%d", i), Writes: []string{fmt.Sprintf("k.%d", i)}}
+		for j := 0; j < i; j++ {
+			node.Triggers = append(node.Triggers, fmt.Sprintf("k.%d", j))
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	done := make(chan []FlowFinding)
+	go func() { done <- g.checkCycles(context.Background()) }()
+	select {
+	case findings := <-done:
+		assert.Empty(t, findings)
+	case <-time.After(5 * time.Second):
+		t.Fatal("cycle search did not finish on an acyclic graph")
+	}
+
+	// closing the chain makes every path a cycle; only maxCycles are reported
+	g.Nodes[0].Triggers = []string{"k.59"}
+	assert.Len(t, g.checkCycles(context.Background()), maxCycles)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Empty(t, g.checkCycles(ctx), "a cancelled search stops")
+}
@@ -3,6 +3,7 @@ package checks
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/thinkwright/simplex/lint/internal/parser"
@@ -19,34 +20,90 @@ func NewDeterminismChecker() *DeterminismChecker {
 
 // Check performs all determinism-related checks on the parsed spec.
 func (c *DeterminismChecker) Check(spec *parser.ParsedSpec, r *result.LintResult) {
+	types := ParseDataTypes(spec.DataBlocks)
 	for _, fn := range spec.Functions {
 		if fn.HasDeterminism() {
-			c.checkDeterminismStructure(fn, r)
+			d := ParseDeterminism(fn.GetDeterminism())
+			c.checkDeterminismStructure(fn, d, r)
+			c.checkVaryStable(fn, d, types, r)
+			c.checkEvalInterplay(fn, d, r)
 		}
 	}
 }
 
-// checkDeterminismStructure validates DETERMINISM landmark content.
-// Error E070: DETERMINISM level must be strict, structural, or semantic
-// Error E071: DETERMINISM seed must be a value or "from_input"
-func (c *DeterminismChecker) checkDeterminismStructure(fn parser.FunctionBlock, r *result.LintResult) {
-	content := fn.GetDeterminism()
-	loc := formatFunctionLocation(fn.Name) + " DETERMINISM"
-
-	level := ""
+// Determinism is a parsed DETERMINISM block.
+type Determinism struct {
+	Level   string
+	Seed    string
+	HasSeed bool
+	Vary    []string
+	Stable  []string
+}
 
-	// Parse DETERMINISM content
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
+// ParseDeterminism parses DETERMINISM content. vary and stable take either
+// a comma-separated list on the same line or "- item" lines below it.
+func ParseDeterminism(content string) Determinism {
+	var d Determinism
+	current := ""
+	for _, line := range strings.Split(content, "\n") {
 		trimmed := strings.TrimSpace(line)
 		if trimmed == "" {
 			continue
 		}
 
-		if strings.HasPrefix(trimmed, "level:") {
-			level = strings.TrimSpace(strings.TrimPrefix(trimmed, "level:"))
+		key, value, _ := strings.Cut(trimmed, ":")
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "level":
+			d.Level, current = value, ""
+		case "seed":
+			d.Seed, d.HasSeed, current = strings.Trim(value, `"'`), true, ""
+		case "vary":
+			d.Vary, current = append(d.Vary, splitList(value)...), "vary"
+		case "stable":
+			d.Stable, current = append(d.Stable, splitList(value)...), "stable"
+		default:
+			if !strings.HasPrefix(trimmed, "-") {
+				continue
+			}
+			item := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			switch current {
+			case "vary":
+				d.Vary = append(d.Vary, item)
+			case "stable":
+				d.Stable = append(d.Stable, item)
+			}
 		}
 	}
+	return d
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// validSeeds are the seed values the spec defines.
+var validSeeds = map[string]bool{
+	"from input hash": true,
+	"from timestamp":  true,
+	"none":            true,
+}
+
+// checkDeterminismStructure validates DETERMINISM landmark content.
+// Error E070: DETERMINISM level must be strict, structural, or semantic
+// Error E071: DETERMINISM seed must be from input hash, from timestamp, or none
+// Warning W070: strict determinism should specify seed
+// Warning W071: vary without stable
+func (c *DeterminismChecker) checkDeterminismStructure(fn parser.FunctionBlock, d Determinism, r *result.LintResult) {
+	loc := formatFunctionLocation(fn.Name) + " DETERMINISM"
+	level := d.Level
 
 	// Validate level - required and must be one of strict, structural, semantic
 	if level == "" {
@@ -61,4 +118,117 @@ func (c *DeterminismChecker) checkDeterminismStructure(fn parser.FunctionBlock,
 			r.AddError("E070", fmt.Sprintf("DETERMINISM level must be strict, structural, or semantic, got: %s", level), loc)
 		}
 	}
+
+	if d.HasSeed && !validSeeds[strings.ToLower(strings.Join(strings.Fields(d.Seed), " "))] {
+		r.AddError("E071", fmt.Sprintf("DETERMINISM seed must be 'from input hash', 'from timestamp', or 'none', got: %s", d.Seed), loc)
+	}
+	if level == "strict" && !d.HasSeed {
+		r.AddWarningWithSuggestion("W070", "strict determinism should specify seed", loc,
+			"add seed: from input hash, from timestamp, or none", false)
+	}
+	if len(d.Vary) > 0 && len(d.Stable) == 0 {
+		r.AddWarning("W071", "consider specifying stable alongside vary", loc)
+	}
+}
+
+// fieldRefPattern matches a vary or stable entry that names a field, such as
+// total_count or items.score, rather than describing one in prose.
+var fieldRefPattern = regexp.MustCompile(`^[A-Za-z_]\w*(\.[A-Za-z_]\w*)*$`)
+
+// checkVaryStable checks vary and stable against each other and against the
+// DATA type the function returns. Entries written as prose ("ordering of
+// items") are only compared with each other.
+// Error E072: field listed in both vary and stable
+// Warning W073: vary or stable names a field not in the return DATA
+func (c *DeterminismChecker) checkVaryStable(fn parser.FunctionBlock, d Determinism, types map[string]*DataType, r *result.LintResult) {
+	loc := formatFunctionLocation(fn.Name) + " DETERMINISM"
+
+	stable := make(map[string]bool, len(d.Stable))
+	for _, s := range d.Stable {
+		stable[normalizeEntry(s)] = true
+	}
+	for _, v := range d.Vary {
+		if stable[normalizeEntry(v)] {
+			r.AddError("E072", fmt.Sprintf("'%s' is listed in both vary and stable", v), loc)
+		}
+	}
+
+	ret := returnDataType(fn, types)
+	if ret == nil {
+		return // no return DATA to check against; E080 covers undefined names
+	}
+	for _, list := range []struct {
+		name    string
+		entries []string
+	}{{"vary", d.Vary}, {"stable", d.Stable}} {
+		for _, entry := range list.entries {
+			if fieldRefPattern.MatchString(entry) && !hasFieldPath(ret, strings.Split(entry, "."), types) {
+				r.AddWarning("W073",
+					fmt.Sprintf("%s names field '%s', which return type %s does not have", list.name, entry, ret.Name), loc)
+			}
+		}
+	}
+}
+
+func normalizeEntry(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// returnDataType returns the DATA type fn returns, or the element type of
+// a returned list, or nil if it returns no defined DATA type.
+func returnDataType(fn parser.FunctionBlock, types map[string]*DataType) *DataType {
+	t, ok := returnTypeRef(fn.ReturnType, types)
+	if !ok {
+		return nil
+	}
+	if t.Kind == KindList {
+		t = *t.Elem
+	}
+	return types[t.Name]
+}
+
+// hasFieldPath reports whether dt has the dotted field path, following
+// references and list elements. A path through an undefined or untyped
+// field is accepted.
+func hasFieldPath(dt *DataType, path []string, types map[string]*DataType) bool {
+	f := dt.Field(path[0])
+	if f == nil {
+		return false
+	}
+	if len(path) == 1 {
+		return true
+	}
+	t := f.Type
+	if t.Kind == KindList {
+		t = *t.Elem
+	}
+	next := types[t.Name]
+	if t.Kind != KindRef || next == nil {
+		return true
+	}
+	return hasFieldPath(next, path[1:], types)
+}
+
+// checkEvalInterplay flags EVAL settings that contradict strict determinism:
+// pass@k accepts runs that differ, and a model grader judges meaning rather
+// than comparing outputs exactly.
+// Warning W072: strict determinism conflicts with EVAL
+func (c *DeterminismChecker) checkEvalInterplay(fn parser.FunctionBlock, d Determinism, r *result.LintResult) {
+	if d.Level != "strict" || !fn.HasEval() {
+		return
+	}
+	loc := formatFunctionLocation(fn.Name) + " DETERMINISM"
+
+	for _, line := range strings.Split(fn.GetEval(), "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), ":")
+		value = strings.TrimSpace(value)
+		switch {
+		case key == "evolve" && strings.HasPrefix(value, "pass@"):
+			r.AddWarning("W072",
+				fmt.Sprintf("strict determinism with evolve threshold %s: pass@k accepts runs whose outputs differ", value), loc)
+		case key == "grading" && value == "model":
+			r.AddWarning("W072",
+				"strict determinism with grading: model: a model grader judges meaning, not identical outputs", loc)
+		}
+	}
 }
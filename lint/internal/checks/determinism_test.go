@@ -0,0 +1,134 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func checkDeterminism(t *testing.T, spec string) *result.LintResult {
+	t.Helper()
+	parsed := parser.NewParser().Parse(spec)
+	r := result.NewLintResult("test.md")
+	NewDeterminismChecker().Check(parsed, r)
+	return r
+}
+
+func issueCodes(r *result.LintResult) []string {
+	var codes []string
+	for _, e := range r.Issues() {
+		codes = append(codes, e.Code)
+	}
+	return codes
+}
+
+func TestParseDeterminism(t *testing.T) {
+	d := ParseDeterminism(`level: structural
+seed: "from input hash"
+vary: ordering of items, whitespace
+stable:
+  - total_count
+  - query`)
+
+	assert.Equal(t, "structural", d.Level)
+	assert.True(t, d.HasSeed)
+	assert.Equal(t, "from input hash", d.Seed)
+	assert.Equal(t, []string{"ordering of items", "whitespace"}, d.Vary)
+	assert.Equal(t, []string{"total_count", "query"}, d.Stable)
+}
+
+func TestDeterminismChecker_Seed(t *testing.T) {
+	tests := []struct {
+		name  string
+		block string
+		want  []string
+	}{
+		{"valid seed", "level: strict\n  seed: from input hash", nil},
+		{"seed is case and space insensitive", "level: strict\n  seed: From  Timestamp", nil},
+		{"none", "level: semantic\n  seed: none", nil},
+		{"E071 invalid seed", "level: strict\n  seed: 42", []string{"E071"}},
+		{"W070 strict without seed", "level: strict", []string{"W070"}},
+		{"no seed needed below strict", "level: structural", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := checkDeterminism(t, "FUNCTION: f(x) → string\n\nDETERMINISM:\n  "+tt.block+"\n")
+			assert.Equal(t, tt.want, issueCodes(r))
+		})
+	}
+}
+
+func TestDeterminismChecker_VaryStable(t *testing.T) {
+	r := checkDeterminism(t, `DATA: Page
+  items: list of Item
+  total_count: integer
+
+DATA: Item
+  id: string
+  score: number
+
+FUNCTION: search(query) → Page
+
+DETERMINISM:
+  level: structural
+  vary: ordering of items, total_count, items.rank
+  stable: total_count, items.id, cursor
+`)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E072", r.Errors[0].Code)
+	assert.Equal(t, "'total_count' is listed in both vary and stable", r.Errors[0].Message)
+
+	var messages []string
+	for _, w := range r.Warnings {
+		assert.Equal(t, "W073", w.Code)
+		messages = append(messages, w.Message)
+	}
+	assert.Equal(t, []string{
+		"vary names field 'items.rank', which return type Page does not have",
+		"stable names field 'cursor', which return type Page does not have",
+	}, messages)
+}
+
+func TestDeterminismChecker_W071_VaryWithoutStable(t *testing.T) {
+	r := checkDeterminism(t, `FUNCTION: search(query) → results
+
+DETERMINISM:
+  level: structural
+  vary: ordering of results
+`)
+
+	assert.Equal(t, []string{"W071"}, issueCodes(r))
+}
+
+func TestDeterminismChecker_W072_StrictWithLooseEval(t *testing.T) {
+	r := checkDeterminism(t, `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict
+  seed: from input hash
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: model
+`)
+
+	require.Len(t, r.Warnings, 2)
+	assert.Contains(t, r.Warnings[0].Message, "evolve threshold pass@5")
+	assert.Contains(t, r.Warnings[1].Message, "grading: model")
+
+	r = checkDeterminism(t, `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: structural
+
+EVAL:
+  evolve: pass@5
+  grading: model
+`)
+	assert.Empty(t, r.Warnings, "only strict conflicts with pass@k")
+}
@@ -0,0 +1,230 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// ErrorsConfig holds the term list that marks an ERRORS item as the
+// catch-all (see CompileTerms for the entry syntax).
+type ErrorsConfig struct {
+	// CatchAll terms mark the condition of an item that handles every error
+	// the other items don't name.
+	CatchAll []string
+}
+
+// DefaultErrorsConfig returns the built-in term list.
+func DefaultErrorsConfig() ErrorsConfig {
+	return ErrorsConfig{
+		CatchAll: []string{
+			"unhandled", "unexpected", "any other", "anything else", "all other",
+			"otherwise", "catch-all",
+			`/\bany\s+(\w+\s+)?(condition|error|failure|exception)s?\b/`,
+		},
+	}
+}
+
+// ErrorsChecker checks that ERRORS has a catch-all and that its error paths
+// agree with EXAMPLES, without an LLM.
+type ErrorsChecker struct {
+	catchAll []*regexp.Regexp
+}
+
+// NewErrorsChecker creates an ErrorsChecker with the default terms.
+func NewErrorsChecker() *ErrorsChecker {
+	return NewErrorsCheckerWithConfig(DefaultErrorsConfig())
+}
+
+// NewErrorsCheckerWithConfig creates an ErrorsChecker with custom terms.
+// Invalid patterns are skipped; validate them with CompileTerms.
+func NewErrorsCheckerWithConfig(config ErrorsConfig) *ErrorsChecker {
+	return &ErrorsChecker{catchAll: mustCompileTerms(config.CatchAll)}
+}
+
+// ErrorItem is one item of an ERRORS block, e.g.
+// `file not found → fail with "file not found: {path}"`.
+type ErrorItem struct {
+	Index     int    // 1-based position in the block
+	Text      string // the whole item
+	Condition string // text before the arrow
+	Action    string // text after the arrow
+
+	// Messages are the quoted strings of the action.
+	Messages []string
+	// Terms identify the condition in examples.
+	Terms []string
+	// CatchAll is set for an item that handles any other condition.
+	CatchAll bool
+}
+
+// ParseErrorItems returns the items of an ERRORS block in order. ignore
+// lists words that never identify a condition, such as input names.
+func (c *ErrorsChecker) ParseErrorItems(errors string, ignore []string) []ErrorItem {
+	var items []ErrorItem
+	for i, text := range ExtractRuleItems(errors) {
+		item := ErrorItem{Index: i + 1, Text: text, Condition: text}
+		if idx := arrowPattern.FindStringIndex(text); idx != nil {
+			item.Condition = strings.TrimSpace(text[:idx[0]])
+			item.Action = strings.TrimSpace(text[idx[1]:])
+		}
+		for _, m := range quotedPattern.FindAllStringSubmatch(item.Action, -1) {
+			item.Messages = append(item.Messages, m[1]+m[2])
+		}
+		item.Terms = significantTerms(item.Condition, ignore)
+		phrase, _ := findTerm(c.catchAll, item.Condition)
+		item.CatchAll = phrase != ""
+		items = append(items, item)
+	}
+	return items
+}
+
+var (
+	quotedPattern      = regexp.MustCompile(`"([^"]+)"|'([^']+)'`)
+	placeholderPattern = regexp.MustCompile(`\{[^}]*\}`)
+	// errorOutputPattern matches an example output that reports an error,
+	// e.g. `Error: empty query` or `fail with "not found"`.
+	errorOutputPattern = regexp.MustCompile(`(?i)^(error|fail(s|ed|ure)?(\s+with)?|raises?|throws?|rejects?(\s+with)?|exception)\b[\s:]*`)
+)
+
+// Check reports, for each FUNCTION with ERRORS and EXAMPLES, a missing
+// catch-all item, specific items no example exercises, and examples whose
+// output is an error no specific item names. An example exercises an item
+// when its output shows one of the item's messages, or it shows an error
+// and mentions every term of the item's condition. The catch-all exercises
+// nothing: an error an example shows is specific enough to list.
+// Warning W110: ERRORS has no catch-all item
+// Warning W111: ERRORS item not exercised by any example
+// Warning W112: Example shows an error no ERRORS item names
+func (c *ErrorsChecker) Check(spec *parser.ParsedSpec, r *result.LintResult) {
+	for _, fn := range spec.Functions {
+		if !fn.HasLandmark(parser.LandmarkERRORS) {
+			continue // E005
+		}
+		loc := formatFunctionLocation(fn.Name)
+		items := c.ParseErrorItems(fn.GetErrors(), fn.Inputs)
+
+		catchAll := false
+		for _, item := range items {
+			catchAll = catchAll || item.CatchAll
+		}
+		if !catchAll {
+			r.AddWarningWithSuggestion("W110",
+				"ERRORS has no catch-all item for conditions it doesn't name", loc+" ERRORS",
+				"add: - any unhandled condition → fail with descriptive message", true)
+		}
+
+		examples := ParseExamples(fn.GetExamples())
+		if len(examples) == 0 {
+			continue // E004
+		}
+		exercised := make([]bool, len(items))
+		for i, ex := range examples {
+			matched := false
+			for j, item := range items {
+				if !item.CatchAll && item.exercisedBy(ex) {
+					exercised[j] = true
+					matched = true
+				}
+			}
+			if _, isError := errorText(ex.Output); isError && !matched {
+				r.AddWarningWithSuggestion("W112",
+					fmt.Sprintf("example %d shows an error that no ERRORS item names: %s", i+1, ex.Output), loc+" EXAMPLES",
+					"add an ERRORS item for the condition, with the message the example shows", false)
+			}
+		}
+		for j, item := range items {
+			if !item.CatchAll && !exercised[j] {
+				r.AddWarningWithSuggestion("W111",
+					fmt.Sprintf("ERRORS item %d (%s) is not exercised by any example", item.Index, item.Condition), loc+" ERRORS",
+					"add an example whose input meets the condition and whose output shows the error", false)
+			}
+		}
+	}
+}
+
+// exercisedBy reports whether ex exercises item.
+func (item ErrorItem) exercisedBy(ex Example) bool {
+	text, isError := errorText(ex.Output)
+	for _, msg := range item.Messages {
+		if strings.TrimSpace(placeholderPattern.ReplaceAllString(msg, "")) == "" {
+			continue // all placeholder, e.g. "{details}"
+		}
+		if messagePattern(msg).MatchString(ex.Output) {
+			return true
+		}
+		// A shortened message, e.g. "rate limited" for "rate limited, retry after {seconds}"
+		if text != "" && strings.Contains(strings.ToLower(msg), strings.ToLower(text)) {
+			return true
+		}
+	}
+	if !isError || len(item.Terms) == 0 {
+		return false
+	}
+	for _, t := range item.Terms {
+		if !matchesAny([]string{t}, ex.Input, true) && !matchesAny([]string{t}, ex.Output+" "+ex.Comment, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// messagePattern matches msg case-insensitively, with each {placeholder}
+// standing for any text.
+func messagePattern(msg string) *regexp.Regexp {
+	parts := placeholderPattern.Split(msg, -1)
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return regexp.MustCompile(`(?i)` + strings.Join(parts, `.+`))
+}
+
+// errorText returns the error an example output shows and whether it shows
+// one: the text after a leading "Error:" or "fail with", also inside quotes
+// as in "Error: name required", or the error field of an object. A null,
+// false or empty error field shows none.
+func errorText(output string) (string, bool) {
+	if loc := errorOutputPattern.FindStringIndex(output); loc != nil {
+		return unquote(strings.TrimSpace(output[loc[1]:])), true
+	}
+	if quoted := unquote(output); quoted != output {
+		if loc := errorOutputPattern.FindStringIndex(quoted); loc != nil {
+			return strings.TrimSpace(quoted[loc[1]:]), true
+		}
+		return "", false
+	}
+	v, ok := ParseValue(output)
+	if !ok || v.Kind != ValueObject {
+		return "", false
+	}
+	for _, f := range v.Fields {
+		if f.Key != "error" && f.Key != "errors" {
+			continue
+		}
+		switch f.Value.Kind {
+		case ValueNull:
+			return "", false
+		case ValueBool:
+			return "", strings.EqualFold(f.Value.Text, "true")
+		case ValueString:
+			text := unquote(f.Value.Text)
+			return text, text != ""
+		case ValueList:
+			return "", len(f.Value.Items) > 0
+		default:
+			return unquote(f.Value.Text), true
+		}
+	}
+	return "", false
+}
+
+// unquote strips one pair of matching quotes.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,220 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func checkErrors(t *testing.T, c *ErrorsChecker, examples, errors string) *result.LintResult {
+	t.Helper()
+	spec := `FUNCTION: load(path) → config
+
+RULES:
+  - return the parsed config
+
+DONE_WHEN:
+  - config returned
+
+EXAMPLES:
+` + examples + `
+
+ERRORS:
+` + errors
+
+	r := result.NewLintResult("test.md")
+	c.Check(parser.NewParser().Parse(spec), r)
+	return r
+}
+
+func TestErrorsChecker_ParseErrorItems(t *testing.T) {
+	items := NewErrorsChecker().ParseErrorItems(`
+  - file not found → fail with "file not found: {path}"
+  - invalid YAML -> 'parse error'
+  - any unhandled condition → fail with descriptive message`, []string{"path"})
+
+	require.Len(t, items, 3)
+	assert.Equal(t, "file not found", items[0].Condition)
+	assert.Equal(t, `fail with "file not found: {path}"`, items[0].Action)
+	assert.Equal(t, []string{"file not found: {path}"}, items[0].Messages)
+	assert.Equal(t, []string{"file", "found"}, items[0].Terms)
+	assert.Equal(t, []string{"parse error"}, items[1].Messages)
+	assert.False(t, items[1].CatchAll)
+	assert.True(t, items[2].CatchAll)
+	assert.Equal(t, 3, items[2].Index)
+}
+
+func TestErrorsChecker_CatchAll(t *testing.T) {
+	tests := []struct {
+		condition string
+		catchAll  bool
+	}{
+		{"any unhandled condition", true},
+		{"any error", true},
+		{"any other failure", true},
+		{"unexpected exception", true},
+		{"otherwise", true},
+		{"file not found", false},
+		{"any file missing", false},
+	}
+
+	c := NewErrorsChecker()
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			items := c.ParseErrorItems("- "+tt.condition+" → fail", nil)
+			require.Len(t, items, 1)
+			assert.Equal(t, tt.catchAll, items[0].CatchAll)
+		})
+	}
+}
+
+func TestErrorsChecker_W110_NoCatchAll(t *testing.T) {
+	r := checkErrors(t, NewErrorsChecker(),
+		`  ("a.yaml") → {name: "a"}
+  ("b.yaml") → Error: file not found: b.yaml`,
+		`  - file not found → fail with "file not found: {path}"`)
+
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W110", r.Warnings[0].Code)
+	assert.Equal(t, "FUNCTION load ERRORS", r.Warnings[0].Location)
+	assert.True(t, r.Warnings[0].Fixable)
+
+	r = checkErrors(t, NewErrorsCheckerWithConfig(ErrorsConfig{CatchAll: []string{"everything else"}}),
+		`  ("a.yaml") → {name: "a"}`,
+		`  - everything else → fail`)
+	assert.Empty(t, r.Warnings, "configured catch-all terms")
+}
+
+func TestErrorsChecker_W111_NotExercised(t *testing.T) {
+	r := checkErrors(t, NewErrorsChecker(),
+		`  ("a.yaml") → {name: "a"}
+  ("missing.yaml") → Error: file not found: missing.yaml`,
+		`  - file not found → fail with "file not found: {path}"
+  - invalid YAML → fail with "parse error at line {n}: {details}"
+  - any unhandled condition → fail with descriptive message`)
+
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W111", r.Warnings[0].Code)
+	assert.Equal(t, "ERRORS item 2 (invalid YAML) is not exercised by any example", r.Warnings[0].Message)
+	assert.Equal(t, "FUNCTION load ERRORS", r.Warnings[0].Location)
+}
+
+func TestErrorsChecker_ExercisedBy(t *testing.T) {
+	errors := `  - invalid YAML → fail with "parse error at line {n}: {details}"
+  - rate limit exceeded → fail with "rate limited, retry after {seconds}"
+  - empty path → fail with "path required"
+  - any unhandled condition → fail with descriptive message`
+
+	tests := []struct {
+		name    string
+		example string
+	}{
+		{"message with placeholders", `  ("bad.yaml") → Error: parse error at line 3: unexpected ':'`},
+		{"message in an error field", `  ("bad.yaml") → { error: "Parse error at line 3: tab" }`},
+		{"shortened message", `  ("busy.yaml") → { error: "rate limited", retry_after: 60 }`},
+		{"condition terms", `  ("") → Error: empty path  # nothing to read`},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := checkErrors(t, NewErrorsChecker(), tt.example, errors)
+
+			var unexercised []string
+			for _, w := range r.Warnings {
+				require.Equal(t, "W111", w.Code, w.Message)
+				unexercised = append(unexercised, w.Message)
+			}
+			assert.Len(t, unexercised, 2, "only item %d is exercised", i+1)
+		})
+	}
+}
+
+func TestErrorsChecker_W112_UnnamedError(t *testing.T) {
+	r := checkErrors(t, NewErrorsChecker(),
+		`  ("a.yaml") → { config: {name: "a"}, error: null }
+  ("locked.yaml") → Error: permission denied
+  ("b.yaml") → fail with "file not found: b.yaml"
+  ("c.yaml") → { error: "quota exceeded" }`,
+		`  - file not found → fail with "file not found: {path}"
+  - any unhandled condition → fail with descriptive message`)
+
+	require.Len(t, r.Warnings, 2)
+	assert.Equal(t, "W112", r.Warnings[0].Code)
+	assert.Equal(t, "example 2 shows an error that no ERRORS item names: Error: permission denied", r.Warnings[0].Message)
+	assert.Equal(t, "FUNCTION load EXAMPLES", r.Warnings[0].Location)
+	assert.Equal(t, "W112", r.Warnings[1].Code)
+	assert.Contains(t, r.Warnings[1].Message, "example 4")
+}
+
+func TestErrorsChecker_W112_QuotedError(t *testing.T) {
+	r := checkErrors(t, NewErrorsChecker(),
+		`  ("ann") → "Hello, ann"
+  ("") → "Error: name required"`,
+		`  - any unhandled condition → fail with descriptive message`)
+
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W112", r.Warnings[0].Code)
+	assert.Contains(t, r.Warnings[0].Message, "example 2")
+}
+
+func TestErrorsChecker_MissingLandmarks(t *testing.T) {
+	spec := `FUNCTION: f(x) → y
+
+RULES:
+  - return x
+
+DONE_WHEN:
+  - y returned
+
+EXAMPLES:
+  (1) → Error: bad`
+
+	r := result.NewLintResult("test.md")
+	NewErrorsChecker().Check(parser.NewParser().Parse(spec), r)
+	assert.Empty(t, r.Warnings, "missing ERRORS is E005")
+
+	spec = `FUNCTION: f(x) → y
+
+RULES:
+  - return x
+
+ERRORS:
+  - bad input → fail`
+
+	r = result.NewLintResult("test.md")
+	NewErrorsChecker().Check(parser.NewParser().Parse(spec), r)
+	require.Len(t, r.Warnings, 1, "missing EXAMPLES is E004")
+	assert.Equal(t, "W110", r.Warnings[0].Code)
+}
+
+func TestErrorText(t *testing.T) {
+	tests := []struct {
+		output  string
+		text    string
+		isError bool
+	}{
+		{`Error: empty query`, "empty query", true},
+		{`fail with "not found"`, "not found", true},
+		{`"Error: name required"`, "name required", true},
+		{`'fail with timeout'`, "timeout", true},
+		{`raises ValueError`, "ValueError", true},
+		{`{ error: "unauthorized" }`, "unauthorized", true},
+		{`{ ok: false, errors: ["bad id"] }`, "", true},
+		{`{ success: true, error: null }`, "", false},
+		{`{ error: "" }`, "", false},
+		{`{ error_count: 2 }`, "", false},
+		{`errors_fixed: 3`, "", false},
+		{`"Hello, Alice!"`, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			text, isError := errorText(tt.output)
+			assert.Equal(t, tt.text, text)
+			assert.Equal(t, tt.isError, isError)
+		})
+	}
+}
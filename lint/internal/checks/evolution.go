@@ -4,23 +4,43 @@ package checks
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/thinkwright/simplex/lint/internal/parser"
 	"github.com/thinkwright/simplex/lint/internal/result"
 )
 
+// EvolutionConfig holds thresholds for evolution checks.
+type EvolutionConfig struct {
+	MaxK int // largest plausible k in pass^k and pass@k
+}
+
+// DefaultEvolutionConfig returns the default evolution configuration.
+func DefaultEvolutionConfig() EvolutionConfig {
+	return EvolutionConfig{
+		MaxK: 100,
+	}
+}
+
 // EvolutionChecker performs validation of BASELINE and EVAL landmarks.
 type EvolutionChecker struct {
+	config EvolutionConfig
 	// preservePattern matches pass^k notation (preserve threshold)
 	preservePattern *regexp.Regexp
 	// evolvePattern matches pass@k notation (evolve threshold)
 	evolvePattern *regexp.Regexp
 }
 
-// NewEvolutionChecker creates a new EvolutionChecker.
+// NewEvolutionChecker creates a new EvolutionChecker with default config.
 func NewEvolutionChecker() *EvolutionChecker {
+	return NewEvolutionCheckerWithConfig(DefaultEvolutionConfig())
+}
+
+// NewEvolutionCheckerWithConfig creates a new EvolutionChecker with custom config.
+func NewEvolutionCheckerWithConfig(config EvolutionConfig) *EvolutionChecker {
 	return &EvolutionChecker{
+		config:          config,
 		preservePattern: regexp.MustCompile(`^pass\^(\d+)$`),
 		evolvePattern:   regexp.MustCompile(`^pass@(\d+)$`),
 	}
@@ -32,9 +52,11 @@ func (c *EvolutionChecker) Check(spec *parser.ParsedSpec, r *result.LintResult)
 		c.checkBaselineEvalPair(fn, r)
 		if fn.HasBaseline() {
 			c.checkBaselineStructure(fn, r)
+			c.checkBaselineCoverage(fn, r)
 		}
 		if fn.HasEval() {
 			c.checkEvalStructure(fn, r)
+			c.checkGradingConsistency(fn, r)
 		}
 	}
 }
@@ -118,6 +140,29 @@ func (c *EvolutionChecker) checkBaselineStructure(fn parser.FunctionBlock, r *re
 	}
 }
 
+// checkBaselineCoverage checks that every preserve and evolve item is
+// exercised by an example (see BaselineCoverage).
+// Warning W050: preserve item has no corresponding example
+// Warning W051: evolve item has no corresponding example
+func (c *EvolutionChecker) checkBaselineCoverage(fn parser.FunctionBlock, r *result.LintResult) {
+	if fn.GetExamples() == "" {
+		return // E004 already covers a missing EXAMPLES block
+	}
+	loc := formatFunctionLocation(fn.Name) + " BASELINE"
+	for _, item := range BaselineCoverage(fn) {
+		if len(item.Examples) > 0 {
+			continue
+		}
+		code := "W050"
+		if item.Kind == "evolve" {
+			code = "W051"
+		}
+		r.AddWarningWithSuggestion(code,
+			fmt.Sprintf("%s item '%s' has no corresponding example", item.Kind, item.Text),
+			loc, fmt.Sprintf("add an example that exercises it, tagged [%s %d]", item.Kind, item.Index), false)
+	}
+}
+
 // checkEvalStructure validates EVAL landmark content.
 // Error E061: EVAL requires preserve threshold when BASELINE present
 // Error E062: EVAL requires evolve threshold when BASELINE present
@@ -125,6 +170,7 @@ func (c *EvolutionChecker) checkBaselineStructure(fn parser.FunctionBlock, r *re
 // Error E064: evolve threshold must use pass@k notation
 // Error E065: grading must be code, model, or outcome
 // Error E066: threshold k must be positive integer
+// Warning W066: threshold k exceeds the configured maximum
 func (c *EvolutionChecker) checkEvalStructure(fn parser.FunctionBlock, r *result.LintResult) {
 	content := fn.GetEval()
 	loc := formatFunctionLocation(fn.Name) + " EVAL"
@@ -163,15 +209,19 @@ func (c *EvolutionChecker) checkEvalStructure(fn parser.FunctionBlock, r *result
 
 	// Validate preserve threshold notation (must be pass^k)
 	if preserveThreshold != "" {
-		if !c.preservePattern.MatchString(preserveThreshold) {
+		if m := c.preservePattern.FindStringSubmatch(preserveThreshold); m == nil {
 			r.AddError("E063", fmt.Sprintf("preserve threshold must use pass^k notation, got: %s", preserveThreshold), loc)
+		} else {
+			c.checkThresholdK(preserveThreshold, m[1], loc, r)
 		}
 	}
 
 	// Validate evolve threshold notation (must be pass@k)
 	if evolveThreshold != "" {
-		if !c.evolvePattern.MatchString(evolveThreshold) {
+		if m := c.evolvePattern.FindStringSubmatch(evolveThreshold); m == nil {
 			r.AddError("E064", fmt.Sprintf("evolve threshold must use pass@k notation, got: %s", evolveThreshold), loc)
+		} else {
+			c.checkThresholdK(evolveThreshold, m[1], loc, r)
 		}
 	}
 
@@ -188,3 +238,69 @@ func (c *EvolutionChecker) checkEvalStructure(fn parser.FunctionBlock, r *result
 	}
 }
 
+// checkThresholdK checks the k of a well-formed threshold such as pass^3.
+func (c *EvolutionChecker) checkThresholdK(threshold, digits, loc string, r *result.LintResult) {
+	k, err := strconv.Atoi(digits)
+	switch {
+	case err == nil && k == 0:
+		r.AddError("E066", fmt.Sprintf("threshold k must be positive integer, got: %s", threshold), loc)
+	case err != nil || (c.config.MaxK > 0 && k > c.config.MaxK):
+		r.AddWarningWithSuggestion("W066",
+			fmt.Sprintf("threshold %s runs more trials than is plausible (max_eval_k is %d)", threshold, c.config.MaxK),
+			loc, "use a k that an evaluator can afford to run, e.g. pass^3 or pass@5", false)
+	}
+}
+
+// sideEffectLandmarks declare effects an outcome grader can observe.
+var sideEffectLandmarks = []string{parser.LandmarkWRITES, parser.LandmarkHANDOFF}
+
+// checkGradingConsistency checks that the grading method fits the function:
+// an outcome grader needs side effects to observe, and a code grader needs
+// example outputs it can compare.
+// Warning W067: grading: outcome on a function without side effects
+// Warning W068: grading: code with free-text example outputs
+func (c *EvolutionChecker) checkGradingConsistency(fn parser.FunctionBlock, r *result.LintResult) {
+	loc := formatFunctionLocation(fn.Name) + " EVAL"
+
+	switch evalGrading(fn.GetEval()) {
+	case "outcome":
+		for _, name := range sideEffectLandmarks {
+			if fn.HasLandmark(name) {
+				return
+			}
+		}
+		r.AddWarningWithSuggestion("W067",
+			"grading: outcome but the function declares no side effects (WRITES or HANDOFF)",
+			loc, "declare the effects the grader observes in WRITES, or grade with code or model", false)
+	case "code":
+		var prose []string
+		for i, ex := range ParseExamples(fn.GetExamples()) {
+			if isProse(ex.Output) {
+				prose = append(prose, strconv.Itoa(i+1))
+			}
+		}
+		if len(prose) > 0 {
+			r.AddWarningWithSuggestion("W068",
+				fmt.Sprintf("grading: code but example outputs %s are free-text prose", strings.Join(prose, ", ")),
+				loc, "write outputs as values code can compare, or grade with model", false)
+		}
+	}
+}
+
+// evalGrading returns the grading value of an EVAL block.
+func evalGrading(eval string) string {
+	for _, line := range strings.Split(eval, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "grading:"); ok {
+			value, _ = splitComment(strings.TrimSpace(value))
+			return value
+		}
+	}
+	return ""
+}
+
+// isProse reports whether an example output is a free-text phrase such as
+// "page remains accessible" rather than a value or a placeholder name.
+func isProse(output string) bool {
+	v, ok := ParseValue(output)
+	return ok && v.Kind == ValueWord && len(strings.Fields(v.Text)) >= 3
+}
@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/thinkwright/simplex/lint/internal/parser"
@@ -512,3 +513,204 @@ ERRORS:
 		t.Errorf("Expected no errors for spec without BASELINE/EVAL, got: %v", r.Errors)
 	}
 }
+
+func TestEvolutionChecker_UncoveredBaselineItems(t *testing.T) {
+	spec := `
+FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+    - data integrity maintained
+  evolve:
+    - add indexes
+    - faster full-text search
+
+RULES:
+  - migrate the schema
+
+DONE_WHEN:
+  - report lists migrated tables
+
+EXAMPLES:
+  (orders_db) → report listing new indexes
+  (legacy_query) → same rows as before
+
+ERRORS:
+  - any → fail
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: model
+`
+
+	p := parser.NewParser()
+	parsed := p.Parse(spec)
+	r := result.NewLintResult("test")
+
+	checker := NewEvolutionChecker()
+	checker.Check(parsed, r)
+
+	var got []string
+	for _, w := range r.Warnings {
+		got = append(got, w.Code+" "+w.Message)
+	}
+	want := []string{
+		"W050 preserve item 'data integrity maintained' has no corresponding example",
+		"W051 evolve item 'faster full-text search' has no corresponding example",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d warnings, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], got[i])
+		}
+	}
+}
+
+func TestEvolutionChecker_TaggedExamplesCoverBaselineItems(t *testing.T) {
+	spec := `
+FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+    - data integrity maintained
+  evolve:
+    - faster full-text search
+
+RULES:
+  - migrate the schema
+
+DONE_WHEN:
+  - report lists migrated tables
+
+EXAMPLES:
+  (legacy_query) → same rows as before
+  [preserve 2] (checksummed_db) → matching checksums
+  (docs_db) → report with timings  # [evolve]
+
+ERRORS:
+  - any → fail
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: model
+`
+
+	p := parser.NewParser()
+	parsed := p.Parse(spec)
+	r := result.NewLintResult("test")
+
+	checker := NewEvolutionChecker()
+	checker.Check(parsed, r)
+
+	if len(r.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", r.Warnings)
+	}
+}
+
+func evalSpec(eval, examples, extra string) string {
+	return `
+FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+RULES:
+  - migrate the schema
+
+DONE_WHEN:
+  - report lists migrated tables
+
+EXAMPLES:
+` + examples + `
+
+ERRORS:
+  - any → fail
+` + extra + `
+EVAL:
+` + eval + "\n"
+}
+
+func codesByCode(r *result.LintResult) map[string]int {
+	counts := make(map[string]int)
+	for _, e := range r.Issues() {
+		counts[e.Code]++
+	}
+	return counts
+}
+
+func TestEvolutionChecker_ThresholdK(t *testing.T) {
+	examples := "  (legacy_query) → same_rows\n  (orders_db) → new_indexes"
+	tests := []struct {
+		eval string
+		want map[string]int
+	}{
+		{"  preserve: pass^3\n  evolve: pass@5\n  grading: code", map[string]int{}},
+		{"  preserve: pass^0\n  evolve: pass@5\n  grading: code", map[string]int{"E066": 1}},
+		{"  preserve: pass^3\n  evolve: pass@00\n  grading: code", map[string]int{"E066": 1}},
+		{"  preserve: pass^3\n  evolve: pass@500\n  grading: code", map[string]int{"W066": 1}},
+		{"  preserve: pass^99999999999999999999\n  evolve: pass@5\n  grading: code", map[string]int{"W066": 1}},
+	}
+	for _, tt := range tests {
+		p := parser.NewParser()
+		r := result.NewLintResult("test")
+		NewEvolutionChecker().Check(p.Parse(evalSpec(tt.eval, examples, "")), r)
+		if got := codesByCode(r); len(got) != len(tt.want) || got["E066"] != tt.want["E066"] || got["W066"] != tt.want["W066"] {
+			t.Errorf("%q: expected %v, got %v", tt.eval, tt.want, got)
+		}
+	}
+
+	r := result.NewLintResult("test")
+	NewEvolutionCheckerWithConfig(EvolutionConfig{MaxK: 4}).Check(
+		parser.NewParser().Parse(evalSpec("  preserve: pass^3\n  evolve: pass@5\n  grading: code", examples, "")), r)
+	if got := codesByCode(r); got["W066"] != 1 {
+		t.Errorf("Expected W066 for pass@5 with MaxK 4, got %v", got)
+	}
+}
+
+func TestEvolutionChecker_GradingConsistency(t *testing.T) {
+	tests := []struct {
+		name     string
+		grading  string
+		examples string
+		extra    string
+		want     string
+	}{
+		{"outcome without side effects", "outcome", "  (orders_db) → new_indexes", "", "W067"},
+		{"outcome with WRITES", "outcome", "  (orders_db) → new_indexes", "\nWRITES:\n  - schema_version\n", ""},
+		{"code with prose output", "code", "  (legacy_query) → same rows as before\n  (orders_db) → { indexes: 2 }", "", "W068"},
+		{"code with values", "code", "  (legacy_query) → same_rows\n  (bad) → Error: unknown table", "", ""},
+		{"model with prose output", "model", "  (legacy_query) → same rows as before", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := result.NewLintResult("test")
+			spec := evalSpec("  preserve: pass^3\n  evolve: pass@5\n  grading: "+tt.grading, tt.examples, tt.extra)
+			NewEvolutionChecker().Check(parser.NewParser().Parse(spec), r)
+
+			var got []string
+			for _, w := range r.Warnings {
+				if w.Code == "W067" || w.Code == "W068" {
+					got = append(got, w.Code)
+				}
+			}
+			if tt.want == "" && len(got) > 0 || tt.want != "" && (len(got) != 1 || got[0] != tt.want) {
+				t.Errorf("Expected %q, got %v", tt.want, got)
+			}
+			if tt.want == "W068" && !strings.Contains(r.Warnings[len(r.Warnings)-1].Message, "example outputs 1 are free-text prose") {
+				t.Errorf("Expected prose examples listed, got %q", r.Warnings[len(r.Warnings)-1].Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// Observability classifies a DONE_WHEN item.
+type Observability string
+
+// DONE_WHEN item classes.
+const (
+	Observable         Observability = "observable"
+	NeedsClarification Observability = "needs_clarification"
+	NotObservable      Observability = "not_observable"
+)
+
+// ObservabilityConfig holds the term lists the observability check matches
+// DONE_WHEN items against (see CompileTerms for the entry syntax).
+type ObservabilityConfig struct {
+	// NotObservable terms refer to state only the implementation can see.
+	NotObservable []string
+	// Vague terms describe completion without saying how it shows.
+	Vague []string
+	// Observable terms anchor a vague item to something a caller can check.
+	Observable []string
+}
+
+// DefaultObservabilityConfig returns the built-in term lists.
+func DefaultObservabilityConfig() ObservabilityConfig {
+	return ObservabilityConfig{
+		NotObservable: []string{
+			"internal state",
+			`/\binternal(ly)?\s+\w+/`,
+			"variable", "variables",
+			"data structure", "data structures",
+			`/\b(local|temporary|intermediate|private)\s+(variable|value|field|state|buffer|copy)s?\b/`,
+			"in memory",
+		},
+		Vague: []string{
+			"processed", "handled", "completed", "finished", "done",
+			"works", "working", "correctly", "properly", "successfully",
+			"appropriately", "as expected", "ready",
+		},
+		Observable: []string{
+			"output", "outputs", "return", "returns", "returned", "result",
+			"response", "responds", "written", "writes", "file", "files",
+			"log", "logged", "emitted", "emits", "sent", "published",
+			"displayed", "shown", "printed", "stdout", "stderr", "exit code",
+			"status code", "error", "errors", "raised", "SharedMemory",
+			"shared memory", "stored", "persisted", "database", "contains",
+			"visible",
+		},
+	}
+}
+
+// ObservabilityChecker checks that DONE_WHEN items can be verified from
+// outside the function, without an LLM.
+type ObservabilityChecker struct {
+	notObservable []*regexp.Regexp
+	vague         []*regexp.Regexp
+	observable    []*regexp.Regexp
+}
+
+// NewObservabilityChecker creates an ObservabilityChecker with the default terms.
+func NewObservabilityChecker() *ObservabilityChecker {
+	return NewObservabilityCheckerWithConfig(DefaultObservabilityConfig())
+}
+
+// NewObservabilityCheckerWithConfig creates an ObservabilityChecker with custom
+// terms. Invalid patterns are skipped; validate them with CompileTerms.
+func NewObservabilityCheckerWithConfig(config ObservabilityConfig) *ObservabilityChecker {
+	return &ObservabilityChecker{
+		notObservable: mustCompileTerms(config.NotObservable),
+		vague:         mustCompileTerms(config.Vague),
+		observable:    mustCompileTerms(config.Observable),
+	}
+}
+
+// Check classifies every DONE_WHEN item.
+// Error E030: DONE_WHEN item is not observable
+// Warning W030: DONE_WHEN item needs clarification
+func (c *ObservabilityChecker) Check(spec *parser.ParsedSpec, r *result.LintResult) {
+	for _, fn := range spec.Functions {
+		doneWhen := fn.GetDoneWhen()
+		if doneWhen == "" {
+			continue
+		}
+		loc := formatFunctionLocation(fn.Name) + " DONE_WHEN"
+
+		for i, item := range ExtractRuleItems(doneWhen) {
+			class, phrase, marked := c.classify(item)
+			switch class {
+			case NotObservable:
+				r.AddErrorWithSuggestion("E030",
+					fmt.Sprintf("DONE_WHEN item %d is not observable: %q refers to %q", i+1, marked, phrase),
+					loc,
+					"state the criterion in terms of outputs, return values or side effects", false)
+			case NeedsClarification:
+				r.AddWarningWithSuggestion("W030",
+					fmt.Sprintf("DONE_WHEN item %d may not be observable without clarification: %q", i+1, marked),
+					loc,
+					fmt.Sprintf("say how %q shows in the output or side effects", phrase), false)
+			}
+		}
+	}
+}
+
+// Classify returns the class of a DONE_WHEN item and the phrase that
+// decided it, empty for observable items.
+//
+// An item mentioning a not-observable term is not observable. An item with
+// a vague term and no observable term needs clarification. Anything else
+// is observable.
+func (c *ObservabilityChecker) Classify(item string) (Observability, string) {
+	class, phrase, _ := c.classify(item)
+	return class, phrase
+}
+
+// classify is Classify that also returns the item with the phrase highlighted.
+func (c *ObservabilityChecker) classify(item string) (Observability, string, string) {
+	if phrase, loc := findTerm(c.notObservable, item); loc != nil {
+		return NotObservable, phrase, highlight(item, loc)
+	}
+	if phrase, loc := findTerm(c.vague, item); loc != nil {
+		if anchor, _ := findTerm(c.observable, item); anchor == "" {
+			return NeedsClarification, phrase, highlight(item, loc)
+		}
+	}
+	return Observable, "", item
+}
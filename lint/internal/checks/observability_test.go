@@ -0,0 +1,99 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func TestObservabilityChecker_Classify(t *testing.T) {
+	tests := []struct {
+		item   string
+		class  Observability
+		phrase string
+	}{
+		{"output contains no duplicates", Observable, ""},
+		{"internal counter reaches zero", NotObservable, "internal counter"},
+		{"the retry variable is reset", NotObservable, "variable"},
+		{"cache data structure is rebuilt", NotObservable, "data structure"},
+		{"all items processed", NeedsClarification, "processed"},
+		{"all items processed and returned in order", Observable, ""},
+		{"report written to SharedMemory.reports", Observable, ""},
+		{"internal state is consistent after the result is returned", NotObservable, "internal state"},
+	}
+
+	c := NewObservabilityChecker()
+	for _, tt := range tests {
+		t.Run(tt.item, func(t *testing.T) {
+			class, phrase := c.Classify(tt.item)
+			assert.Equal(t, tt.class, class)
+			assert.Equal(t, tt.phrase, phrase)
+		})
+	}
+}
+
+func TestObservabilityChecker_Check(t *testing.T) {
+	spec := `FUNCTION: dedupe(items) → unique
+
+RULES:
+  - remove repeated items
+
+DONE_WHEN:
+  - output contains no duplicates
+  - internal counter reaches zero
+  - all items processed
+
+EXAMPLES:
+  ([a, a]) → [a]
+
+ERRORS:
+  - fail`
+
+	r := result.NewLintResult("test.md")
+	NewObservabilityChecker().Check(parser.NewParser().Parse(spec), r)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E030", r.Errors[0].Code)
+	assert.Equal(t, `DONE_WHEN item 2 is not observable: "[internal counter] reaches zero" refers to "internal counter"`, r.Errors[0].Message)
+	assert.Equal(t, "FUNCTION dedupe DONE_WHEN", r.Errors[0].Location)
+
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W030", r.Warnings[0].Code)
+	assert.Equal(t, `DONE_WHEN item 3 may not be observable without clarification: "all items [processed]"`, r.Warnings[0].Message)
+}
+
+func TestObservabilityChecker_CustomTerms(t *testing.T) {
+	c := NewObservabilityCheckerWithConfig(ObservabilityConfig{
+		NotObservable: []string{`/\bretry budget\b/`},
+		Vague:         []string{"goes well"},
+	})
+
+	class, phrase := c.Classify("Retry budget is spent")
+	assert.Equal(t, NotObservable, class)
+	assert.Equal(t, "Retry budget", phrase)
+
+	class, _ = c.Classify("upload goes well")
+	assert.Equal(t, NeedsClarification, class)
+
+	class, _ = c.Classify("internal state is consistent")
+	assert.Equal(t, Observable, class, "configured lists replace the defaults")
+}
+
+func TestCompileTerms(t *testing.T) {
+	patterns, err := CompileTerms([]string{"data structure", "/count(er)?s?/", " "})
+	require.NoError(t, err)
+	assert.Len(t, patterns, 2)
+
+	phrase, loc := findTerm(patterns, "the data  structure holds counters")
+	assert.Equal(t, "data  structure", phrase)
+	assert.Equal(t, "the [data  structure] holds counters", highlight("the data  structure holds counters", loc))
+
+	phrase, _ = findTerm(patterns, "metadata structures")
+	assert.Empty(t, phrase, "phrases match on word boundaries")
+
+	_, err = CompileTerms([]string{"/(unclosed/"})
+	assert.Error(t, err)
+}
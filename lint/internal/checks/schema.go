@@ -0,0 +1,208 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// SchemaConfig holds settings for output schema checks.
+type SchemaConfig struct {
+	// BuiltinTypes replaces DefaultBuiltinTypes when not nil.
+	BuiltinTypes []string
+}
+
+// SchemaChecker checks example outputs against the DATA type a function
+// returns, which v0.5 makes a required output schema.
+type SchemaChecker struct {
+	builtin map[string]bool
+}
+
+// NewSchemaChecker creates a SchemaChecker with the default builtin types.
+func NewSchemaChecker() *SchemaChecker {
+	return NewSchemaCheckerWithConfig(SchemaConfig{})
+}
+
+// NewSchemaCheckerWithConfig creates a SchemaChecker with custom config.
+func NewSchemaCheckerWithConfig(config SchemaConfig) *SchemaChecker {
+	return &SchemaChecker{builtin: builtinSet(config.BuiltinTypes)}
+}
+
+// schemaIssue is a finding about one example output.
+type schemaIssue struct {
+	code    string
+	message string // completes "example N output ..."
+}
+
+// Check validates the example outputs of functions that return DATA types.
+// Error E080: Return type references undefined DATA block
+// Error E081: Example output missing required field
+// Error E082: Example output field has wrong type
+// Warning W080: Example output contains field not in schema
+func (c *SchemaChecker) Check(spec *parser.ParsedSpec, r *result.LintResult) {
+	// As with W006, a spec without DATA blocks isn't using typed outputs
+	if len(spec.DataBlocks) == 0 {
+		return
+	}
+	types := ParseDataTypes(spec.DataBlocks)
+
+	for _, fn := range spec.Functions {
+		t, ok := returnTypeRef(fn.ReturnType, types)
+		if !ok {
+			continue
+		}
+		name := t.Name
+		if t.Kind == KindList {
+			name = t.Elem.Name
+		}
+		if c.builtin[strings.ToLower(name)] {
+			continue
+		}
+		if types[name] == nil {
+			r.AddErrorWithSuggestion("E080",
+				fmt.Sprintf("return type '%s' references undefined DATA block", name),
+				formatFunctionLocation(fn.Name),
+				fmt.Sprintf("add DATA: %s with the fields outputs must have", name), false)
+			continue
+		}
+
+		loc := formatFunctionLocation(fn.Name) + " EXAMPLES"
+		for i, ex := range ParseExamples(fn.GetExamples()) {
+			v, ok := ParseValue(ex.Output)
+			if !ok {
+				continue // prose or an error, not an output value
+			}
+			for _, issue := range checkValue(t, v, "", types) {
+				msg := fmt.Sprintf("example %d output %s", i+1, issue.message)
+				if issue.code == "W080" {
+					r.AddWarning(issue.code, msg, loc)
+				} else {
+					r.AddError(issue.code, msg, loc)
+				}
+			}
+		}
+	}
+}
+
+// checkValue checks v against t. path names v within the output, e.g.
+// "details[0]", and is empty for the output itself.
+func checkValue(t Type, v Value, path string, types map[string]*DataType) []schemaIssue {
+	if v.Kind == ValueWord && t.Kind != KindEnum {
+		return nil // a placeholder such as User or total_price
+	}
+
+	switch t.Kind {
+	case KindAny, KindUnknown:
+		return nil
+	case KindString:
+		if v.Kind == ValueString {
+			return nil
+		}
+	case KindNumber:
+		if v.Kind == ValueNumber {
+			return nil
+		}
+	case KindInteger:
+		if v.Kind == ValueNumber && !strings.ContainsAny(v.Text, ".eE") {
+			return nil
+		}
+	case KindBoolean:
+		if v.Kind == ValueBool {
+			return nil
+		}
+	case KindNull:
+		if v.Kind == ValueNull {
+			return nil
+		}
+	case KindEnum:
+		if v.Kind != ValueObject && v.Kind != ValueList && v.Kind != ValueNull {
+			text := strings.Trim(v.Text, `"'`)
+			for _, allowed := range t.Values {
+				if strings.EqualFold(text, allowed) {
+					return nil
+				}
+			}
+		}
+	case KindList:
+		if v.Kind == ValueList {
+			var issues []schemaIssue
+			for i, item := range v.Items {
+				issues = append(issues, checkValue(*t.Elem, item, fmt.Sprintf("%s[%d]", path, i), types)...)
+			}
+			return issues
+		}
+	case KindRef:
+		dt := types[t.Name]
+		if dt == nil {
+			return nil // undefined nested types are reported elsewhere
+		}
+		if v.Kind == ValueObject {
+			return checkObject(dt, v, path, types)
+		}
+	case KindUnion:
+		for _, alt := range t.Alternatives {
+			if len(checkValue(alt, v, path, types)) == 0 {
+				return nil
+			}
+		}
+	}
+	return []schemaIssue{wrongType(t, v, path)}
+}
+
+// checkObject checks the fields of an object value against a DATA type.
+func checkObject(dt *DataType, v Value, path string, types map[string]*DataType) []schemaIssue {
+	var issues []schemaIssue
+	present := make(map[string]Value, len(v.Fields))
+	for _, f := range v.Fields {
+		present[f.Key] = f.Value
+	}
+
+	for _, field := range dt.Fields {
+		value, ok := present[field.Name]
+		fieldPath := joinPath(path, field.Name)
+		switch {
+		case field.Presence == NotAllowed:
+			if ok {
+				issues = append(issues, schemaIssue{"W080",
+					fmt.Sprintf("contains field '%s', which %s marks not allowed", fieldPath, dt.Name)})
+			}
+		case !ok:
+			if field.Presence == Required {
+				issues = append(issues, schemaIssue{"E081",
+					fmt.Sprintf("missing required field '%s' of %s", fieldPath, dt.Name)})
+			}
+		case value.Kind == ValueNull && field.Presence != Required:
+			// an absent optional or conditional field written as null
+		default:
+			issues = append(issues, checkValue(field.Type, value, fieldPath, types)...)
+		}
+	}
+
+	for _, f := range v.Fields {
+		if dt.Field(f.Key) == nil {
+			issues = append(issues, schemaIssue{"W080",
+				fmt.Sprintf("contains field '%s' not in schema %s", joinPath(path, f.Key), dt.Name)})
+		}
+	}
+	return issues
+}
+
+func wrongType(t Type, v Value, path string) schemaIssue {
+	got := string(v.Kind)
+	if v.Text != "" {
+		got = v.Text
+	}
+	if path == "" {
+		return schemaIssue{"E082", fmt.Sprintf("has wrong type: expected %s, got %s", t, got)}
+	}
+	return schemaIssue{"E082", fmt.Sprintf("field '%s' has wrong type: expected %s, got %s", path, t, got)}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
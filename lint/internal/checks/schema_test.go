@@ -0,0 +1,159 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+const schemaData = `DATA: Finding
+  code: string
+  severity: critical | warning | info
+
+DATA: Report
+  passed: boolean
+  count: integer
+  findings: list of Finding
+  owner: User | null
+  note: string, optional
+  error: string, present when the check fails
+  secret: string, not allowed
+
+DATA: User
+  name: string
+`
+
+func checkSchema(t *testing.T, spec string) *result.LintResult {
+	t.Helper()
+	parsed := parser.NewParser().Parse(spec)
+	r := result.NewLintResult("test.md")
+	NewSchemaChecker().Check(parsed, r)
+	return r
+}
+
+func TestSchemaChecker_ValidOutputs(t *testing.T) {
+	r := checkSchema(t, schemaData+`
+FUNCTION: audit(spec) → Report
+
+EXAMPLES:
+  (clean) → { passed: true, count: 0, findings: [], owner: null }
+  (dirty) → { passed: false, count: 1, findings: [{ code: "E1", severity: critical }], owner: { name: "ada" }, note: null }
+  (broken) → Error: spec unreadable
+`)
+
+	assert.Empty(t, r.Errors)
+	assert.Empty(t, r.Warnings)
+}
+
+func TestSchemaChecker_E080_UndefinedReturnType(t *testing.T) {
+	r := checkSchema(t, schemaData+`
+FUNCTION: audit(spec) → Reprot
+
+EXAMPLES:
+  (clean) → { passed: true }
+`)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E080", r.Errors[0].Code)
+	assert.Contains(t, r.Errors[0].Message, "'Reprot'")
+	assert.Equal(t, "FUNCTION audit", r.Errors[0].Location)
+}
+
+func TestSchemaChecker_PluralListElement(t *testing.T) {
+	r := checkSchema(t, `DATA: Status
+  code: string
+
+DATA: Address
+  street: string
+
+FUNCTION: history(order) → list of Status
+
+EXAMPLES:
+  (order) → [{ code: "sent" }]
+
+FUNCTION: stops(route) → list of Addresses
+
+EXAMPLES:
+  (route) → [{ street: "Main St" }]
+`)
+
+	assert.Empty(t, r.Errors, "Status is not read as Statu")
+	assert.Empty(t, r.Warnings)
+}
+
+func TestSchemaChecker_NoDataBlocks(t *testing.T) {
+	r := checkSchema(t, `FUNCTION: login(creds) → Session
+
+EXAMPLES:
+  (valid) → { id: "s1" }
+`)
+
+	assert.Empty(t, r.Errors)
+}
+
+func TestSchemaChecker_E081_MissingRequiredField(t *testing.T) {
+	r := checkSchema(t, schemaData+`
+FUNCTION: audit(spec) → Report
+
+EXAMPLES:
+  (clean) → { passed: true, findings: [], owner: null }
+`)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E081", r.Errors[0].Code)
+	assert.Equal(t, "example 1 output missing required field 'count' of Report", r.Errors[0].Message)
+	assert.Equal(t, "FUNCTION audit EXAMPLES", r.Errors[0].Location)
+}
+
+func TestSchemaChecker_E082_WrongType(t *testing.T) {
+	r := checkSchema(t, schemaData+`
+FUNCTION: audit(spec) → Report
+
+EXAMPLES:
+  (clean) → { passed: "yes", count: 1.5, findings: [{ code: "E1", severity: fatal }], owner: 3 }
+`)
+
+	var messages []string
+	for _, e := range r.Errors {
+		assert.Equal(t, "E082", e.Code)
+		messages = append(messages, e.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		`example 1 output field 'passed' has wrong type: expected boolean, got "yes"`,
+		`example 1 output field 'count' has wrong type: expected integer, got 1.5`,
+		`example 1 output field 'findings[0].severity' has wrong type: expected critical | warning | info, got fatal`,
+		`example 1 output field 'owner' has wrong type: expected User | null, got 3`,
+	}, messages)
+}
+
+func TestSchemaChecker_W080_ExtraField(t *testing.T) {
+	r := checkSchema(t, schemaData+`
+FUNCTION: audit(spec) → Report
+
+EXAMPLES:
+  (clean) → { passed: true, count: 0, findings: [], owner: null, duration: 3 }
+  (leaky) → { passed: true, count: 0, findings: [], owner: null, secret: "x" }
+`)
+
+	assert.Empty(t, r.Errors)
+	require.Len(t, r.Warnings, 2)
+	assert.Equal(t, "W080", r.Warnings[0].Code)
+	assert.Equal(t, "example 1 output contains field 'duration' not in schema Report", r.Warnings[0].Message)
+	assert.Equal(t, "example 2 output contains field 'secret', which Report marks not allowed", r.Warnings[1].Message)
+}
+
+func TestSchemaChecker_ListReturnType(t *testing.T) {
+	r := checkSchema(t, schemaData+`
+FUNCTION: findings(spec) → list of Finding
+
+EXAMPLES:
+  (clean) → []
+  (dirty) → [{ code: "E1", severity: info }, { severity: warning }]
+`)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "example 2 output missing required field '[1].code' of Finding", r.Errors[0].Message)
+}
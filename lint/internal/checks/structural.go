@@ -3,19 +3,38 @@ package checks
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/thinkwright/simplex/lint/internal/parser"
 	"github.com/thinkwright/simplex/lint/internal/result"
 )
 
+// StructuralConfig holds options for structural checks.
+type StructuralConfig struct {
+	// OutputSchemas is set when SchemaChecker runs (v0.5 on): undefined
+	// return types naming a DATA type are then its E080, not W006.
+	OutputSchemas bool
+
+	// BuiltinTypes replaces DefaultBuiltinTypes when not nil.
+	BuiltinTypes []string
+}
+
 // StructuralChecker performs structural validation of Simplex specs.
-type StructuralChecker struct{}
+type StructuralChecker struct {
+	config StructuralConfig
+}
 
 // NewStructuralChecker creates a new StructuralChecker.
 func NewStructuralChecker() *StructuralChecker {
 	return &StructuralChecker{}
 }
 
+// NewStructuralCheckerWithConfig creates a StructuralChecker with custom config.
+func NewStructuralCheckerWithConfig(config StructuralConfig) *StructuralChecker {
+	return &StructuralChecker{config: config}
+}
+
 // Check performs all structural checks on the parsed spec.
 func (c *StructuralChecker) Check(spec *parser.ParsedSpec, r *result.LintResult) {
 	c.checkFunctionExists(spec, r)
@@ -24,6 +43,7 @@ func (c *StructuralChecker) Check(spec *parser.ParsedSpec, r *result.LintResult)
 	}
 	c.checkRequiredLandmarks(spec, r)
 	c.checkDataReferences(spec, r)
+	c.checkExampleArguments(spec, r)
 }
 
 // checkFunctionExists verifies at least one FUNCTION block exists.
@@ -67,107 +87,240 @@ func (c *StructuralChecker) checkRequiredLandmarks(spec *parser.ParsedSpec, r *r
 	}
 }
 
-// builtinTypes are common primitive/generic types that don't need DATA definitions.
-var builtinTypes = map[string]bool{
-	"string": true, "int": true, "integer": true, "number": true,
-	"bool": true, "boolean": true, "float": true, "double": true,
-	"list": true, "array": true, "map": true, "dict": true,
-	"any": true, "void": true, "none": true, "null": true,
-	"result": true, "output": true, "sum": true, "filtered": true,
-	"valid": true, "issues": true, "timestamp": true, "id": true,
+// DefaultBuiltinTypes are the type names that need no DATA definition:
+// generic containers and placeholder return names. Primitive types such as
+// string, integer and timestamp are always built in.
+var DefaultBuiltinTypes = []string{
+	"list", "array", "map", "dict", "any", "void",
+	"result", "output",
+}
+
+// builtinSet returns the lower-cased names of builtin, or of
+// DefaultBuiltinTypes when builtin is nil.
+func builtinSet(builtin []string) map[string]bool {
+	if builtin == nil {
+		builtin = DefaultBuiltinTypes
+	}
+	set := make(map[string]bool, len(builtin))
+	for _, name := range builtin {
+		set[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	return set
 }
 
-// checkDataReferences verifies that referenced DATA types are defined.
+// bareTypeName matches a return type that is a single word, such as
+// "receipt", which names a type even when it is not capitalized.
+var bareTypeName = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+// checkDataReferences resolves the DATA types that return types, typed
+// inputs such as "items: list of Item" and DATA fields refer to, through
+// lists, unions and enums. A spec without DATA blocks isn't using typed
+// specs and is skipped.
+// Error E006: DATA type defined more than once
 // Warning W006: DATA type referenced but not defined
+// Warning W007: DATA type never referenced
 func (c *StructuralChecker) checkDataReferences(spec *parser.ParsedSpec, r *result.LintResult) {
-	// Build set of defined DATA types
-	definedTypes := make(map[string]bool)
-	for _, data := range spec.DataBlocks {
-		// DATA block content starts with the type name
-		typeName := extractTypeName(data.Content)
-		if typeName != "" {
-			definedTypes[typeName] = true
+	if len(spec.DataBlocks) == 0 {
+		return
+	}
+	builtin := builtinSet(c.config.BuiltinTypes)
+
+	// Parse each block, reporting repeated names; a later block replaces
+	// an earlier one in ParseDataTypes
+	var order []*DataType
+	firstLine := make(map[string]int)
+	for _, block := range spec.DataBlocks {
+		dt := ParseDataType(block.Content)
+		if dt == nil {
+			continue
 		}
+		if line, ok := firstLine[dt.Name]; ok {
+			r.AddError("E006", fmt.Sprintf("DATA type '%s' is already defined on line %d", dt.Name, line), "DATA "+dt.Name)
+			continue
+		}
+		firstLine[dt.Name] = block.LineNumber
+		order = append(order, dt)
+	}
+	types := ParseDataTypes(spec.DataBlocks)
+
+	used := make(map[string]bool)
+	resolve := func(ref string) bool {
+		used[ref] = true
+		return types[ref] != nil || builtin[strings.ToLower(ref)]
 	}
 
-	// Check function signatures for type references
 	for _, fn := range spec.Functions {
-		// Check return type
-		if fn.ReturnType != "" {
-			checkTypeReference(fn.ReturnType, definedTypes, fn.Name, r, spec)
+		loc := formatFunctionLocation(fn.Name)
+
+		t := ParseType(fn.ReturnType).Resolve(types)
+		_, schemaRef := returnTypeRef(fn.ReturnType, types)
+		refs := t.References()
+		if len(refs) == 0 && t.Kind == KindUnknown && bareTypeName.MatchString(fn.ReturnType) {
+			refs = []string{fn.ReturnType}
+		}
+		for _, ref := range refs {
+			// From v0.5 an undefined DATA return type is SchemaChecker's E080
+			if !resolve(ref) && !(c.config.OutputSchemas && schemaRef) {
+				r.AddWarning("W006", fmt.Sprintf("Return type '%s' may reference undefined DATA type", ref), loc)
+			}
+		}
+
+		for _, input := range fn.Inputs {
+			name, t, typed := ParseInput(input)
+			if !typed {
+				continue
+			}
+			for _, ref := range t.Resolve(types).References() {
+				if !resolve(ref) {
+					r.AddWarning("W006", fmt.Sprintf("input '%s' type '%s' references undefined DATA type", name, ref), loc)
+				}
+			}
 		}
 	}
-}
 
-// extractTypeName extracts the type name from DATA block content.
-// DATA content format: "TypeName\n  field: type\n  ..."
-func extractTypeName(content string) string {
-	// First line or first word is the type name
-	for i, ch := range content {
-		if ch == '\n' || ch == ' ' || ch == '\t' {
-			if i > 0 {
-				return content[:i]
+	for _, dt := range order {
+		for _, f := range dt.Fields {
+			for _, ref := range f.Type.Resolve(types).References() {
+				if ref == dt.Name {
+					continue // a self-reference is recursion, not a use
+				}
+				if !resolve(ref) {
+					r.AddWarning("W006", fmt.Sprintf("field '%s' type '%s' references undefined DATA type", f.Name, ref), "DATA "+dt.Name)
+				}
 			}
-			break
 		}
 	}
-	// If no whitespace found, the whole content might be the name
-	if len(content) > 0 && len(content) < 100 {
-		return content
+
+	for _, dt := range order {
+		if !used[dt.Name] {
+			r.AddWarning("W007", fmt.Sprintf("DATA type '%s' is not referenced by any function or DATA type", dt.Name), "DATA "+dt.Name)
+		}
 	}
-	return ""
 }
 
-// checkTypeReference checks if a type reference is valid.
-func checkTypeReference(typeName string, definedTypes map[string]bool, funcName string, r *result.LintResult, spec *parser.ParsedSpec) {
-	// Normalize: lowercase, strip "list of", etc.
-	normalized := normalizeTypeName(typeName)
+// signatureInput is one FUNCTION input as the examples must pass it.
+type signatureInput struct {
+	name     string
+	optional bool // "limit?" or "limit = 10"
+}
 
-	if builtinTypes[normalized] {
-		return
+// signatureInputs parses fn's inputs. It reports false for a signature
+// examples can't be counted against, such as variadic "items...".
+func signatureInputs(fn parser.FunctionBlock) ([]signatureInput, bool) {
+	inputs := make([]signatureInput, 0, len(fn.Inputs))
+	for _, raw := range fn.Inputs {
+		name, _, _ := ParseInput(raw)
+		if strings.Contains(name, "...") || strings.HasPrefix(name, "*") {
+			return nil, false
+		}
+		in := signatureInput{name: name}
+		if before, _, ok := strings.Cut(name, "="); ok {
+			in.name, in.optional = strings.TrimSpace(before), true
+		}
+		if trimmed, ok := strings.CutSuffix(in.name, "?"); ok {
+			in.name, in.optional = trimmed, true
+		}
+		inputs = append(inputs, in)
 	}
+	return inputs, true
+}
 
-	// Check if it's a defined DATA type
-	if definedTypes[normalized] || definedTypes[typeName] {
-		return
-	}
+// checkExampleArguments checks the arguments of each parenthesized example
+// input against the FUNCTION's inputs. Examples written as prose, such as
+// "(a list of three items)", are skipped.
+// Error E007: Example argument count doesn't match FUNCTION inputs
+// Error E008: Example names an argument that isn't a FUNCTION input
+func (c *StructuralChecker) checkExampleArguments(spec *parser.ParsedSpec, r *result.LintResult) {
+	for _, fn := range spec.Functions {
+		if !fn.HasLandmark(parser.LandmarkEXAMPLES) || fn.ReturnType == "" {
+			continue // signature didn't parse
+		}
+		inputs, ok := signatureInputs(fn)
+		if !ok {
+			continue
+		}
+		loc := formatFunctionLocation(fn.Name) + " EXAMPLES"
 
-	// Only report if we have DATA blocks defined (otherwise user isn't using typed specs)
-	if len(spec.DataBlocks) > 0 {
-		r.AddWarning("W006",
-			fmt.Sprintf("Return type '%s' may reference undefined DATA type", typeName),
-			formatFunctionLocation(funcName))
+		for i, ex := range ParseExamples(fn.GetExamples()) {
+			args, ok := ParseArguments(strings.TrimPrefix(ex.Input, fn.Name))
+			if !ok || isProseArguments(args) {
+				continue
+			}
+			c.checkArguments(fn.Name, i+1, inputs, args, loc, r)
+		}
 	}
 }
 
-// normalizeTypeName normalizes a type name for comparison.
-func normalizeTypeName(name string) string {
-	// Convert to lowercase
-	result := make([]byte, 0, len(name))
-	for i := 0; i < len(name); i++ {
-		ch := name[i]
-		if ch >= 'A' && ch <= 'Z' {
-			result = append(result, ch+32) // lowercase
-		} else if ch >= 'a' && ch <= 'z' {
-			result = append(result, ch)
-		} else if ch == ' ' || ch == '_' {
-			// Skip spaces and underscores
-		} else {
-			result = append(result, ch)
+// isProseArguments reports whether any positional argument is a phrase
+// rather than a value or placeholder.
+func isProseArguments(args []Argument) bool {
+	for _, a := range args {
+		if a.Name == "" && a.Value.Kind == ValueWord && strings.ContainsAny(a.Value.Text, " \t") {
+			return true
 		}
 	}
+	return false
+}
 
-	normalized := string(result)
+// checkArguments reports example n's arguments that don't fit inputs.
+// Positional arguments fill inputs in order; named ones must name an input
+// not already filled.
+func (c *StructuralChecker) checkArguments(fnName string, n int, inputs []signatureInput, args []Argument, loc string, r *result.LintResult) {
+	filled := make(map[string]bool)
+	positional := 0
+	for _, a := range args {
+		switch {
+		case a.Name == "":
+			if positional < len(inputs) {
+				filled[inputs[positional].name] = true
+			}
+			positional++
+		case !hasInput(inputs, a.Name):
+			r.AddError("E008", fmt.Sprintf("example %d names argument '%s', which is not an input of %s", n, a.Name, fnName), loc)
+			return
+		case filled[a.Name]:
+			r.AddError("E008", fmt.Sprintf("example %d passes argument '%s' more than once", n, a.Name), loc)
+			return
+		default:
+			filled[a.Name] = true
+		}
+	}
 
-	// Strip common prefixes
-	prefixes := []string{"listof", "arrayof", "setof"}
-	for _, prefix := range prefixes {
-		if len(normalized) > len(prefix) && normalized[:len(prefix)] == prefix {
-			return normalized[len(prefix):]
+	required := 0
+	for _, in := range inputs {
+		if !in.optional {
+			required++
 		}
 	}
+	var missing []string
+	for _, in := range inputs {
+		if !in.optional && !filled[in.name] {
+			missing = append(missing, in.name)
+		}
+	}
+	if positional <= len(inputs) && len(missing) == 0 {
+		return
+	}
 
-	return normalized
+	takes := fmt.Sprintf("%d", len(inputs))
+	if required < len(inputs) {
+		takes = fmt.Sprintf("%d to %d", required, len(inputs))
+	}
+	msg := fmt.Sprintf("example %d passes %d argument(s), but %s takes %s", n, len(args), fnName, takes)
+	if positional <= len(inputs) {
+		msg += fmt.Sprintf(" (missing %s)", strings.Join(missing, ", "))
+	}
+	r.AddError("E007", msg, loc)
+}
+
+// hasInput reports whether name is one of inputs.
+func hasInput(inputs []signatureInput, name string) bool {
+	for _, in := range inputs {
+		if in.name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // formatFunctionLocation formats a function name for error location.
@@ -383,49 +383,216 @@ ERRORS:
 	}
 }
 
-func TestExtractTypeName(t *testing.T) {
-	tests := []struct {
-		name     string
-		content  string
-		expected string
-	}{
-		{"with newline", "User\n  id: string", "User"},
-		{"policy rule", "PolicyRule\n  field: value", "PolicyRule"},
-		{"simple type", "SimpleType", "SimpleType"},
-		{"type with space", "Type ", "Type"},
-		{"type with tab", "Type\tfield", "Type"},
-		{"empty", "", ""},
+func checkStructural(t *testing.T, spec string, config StructuralConfig) *result.LintResult {
+	t.Helper()
+	r := result.NewLintResult("test.md")
+	NewStructuralCheckerWithConfig(config).Check(parser.NewParser().Parse(spec), r)
+	return r
+}
+
+func TestStructuralChecker_DataReferences(t *testing.T) {
+	r := checkStructural(t, `DATA: Cart
+  items: list of Iten
+  owner: Customer | null
+  status: open | closed
+
+DATA: Item
+  sku: string
+  parts: list of Item
+
+FUNCTION: checkout(cart: Cart, coupon: Coupon) → Receipt | Error
+
+RULES:
+  - charge the cart
+
+DONE_WHEN:
+  - receipt issued
+
+EXAMPLES:
+  (cart, none) → receipt
+
+ERRORS:
+  - any unhandled condition → fail`, StructuralConfig{})
+
+	var got []string
+	for _, w := range r.Warnings {
+		got = append(got, w.Code+" ["+w.Location+"] "+w.Message)
 	}
+	assert.Equal(t, []string{
+		"W006 [FUNCTION checkout] Return type 'Receipt' may reference undefined DATA type",
+		"W006 [FUNCTION checkout] Return type 'Error' may reference undefined DATA type",
+		"W006 [FUNCTION checkout] input 'coupon' type 'Coupon' references undefined DATA type",
+		"W006 [DATA Cart] field 'items' type 'Iten' references undefined DATA type",
+		"W006 [DATA Cart] field 'owner' type 'Customer' references undefined DATA type",
+		"W007 [DATA Item] DATA type 'Item' is not referenced by any function or DATA type",
+	}, got, "enum values and the self-reference in Item are not type references")
+}
+
+func TestStructuralChecker_DataReferences_PluralNames(t *testing.T) {
+	r := checkStructural(t, `DATA: Status
+  code: string
+
+DATA: Address
+  street: string
+
+DATA: Shipment
+  stops: list of Addresses
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := extractTypeName(tt.content)
-			assert.Equal(t, tt.expected, result)
-		})
+FUNCTION: track(shipment: Shipment) → list of Status
+
+RULES:
+  - report each status of the shipment
+
+DONE_WHEN:
+  - statuses reported
+
+EXAMPLES:
+  (shipment) → [{ code: "sent" }]
+
+ERRORS:
+  - any unhandled condition → fail`, StructuralConfig{})
+
+	assert.Empty(t, r.Warnings, "Status is defined as written; Addresses names Address")
+}
+
+func TestStructuralChecker_DuplicateData(t *testing.T) {
+	r := checkStructural(t, `DATA: User
+  id: string
+
+DATA: User
+  name: string
+
+FUNCTION: get_user(id) → User
+
+RULES:
+  - find user by id
+
+DONE_WHEN:
+  - user found
+
+EXAMPLES:
+  ("123") → User
+
+ERRORS:
+  - not found → fail`, StructuralConfig{})
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E006", r.Errors[0].Code)
+	assert.Equal(t, "DATA User", r.Errors[0].Location)
+	assert.Equal(t, "DATA type 'User' is already defined on line 1", r.Errors[0].Message)
+}
+
+func TestStructuralChecker_BuiltinTypesConfigurable(t *testing.T) {
+	spec := `DATA: Order
+  total: Money
+
+FUNCTION: price(order: Order) → result
+
+RULES:
+  - price the order
+
+DONE_WHEN:
+  - total set
+
+EXAMPLES:
+  (order) → result
+
+ERRORS:
+  - fail`
+
+	var codes []string
+	for _, w := range checkStructural(t, spec, StructuralConfig{}).Warnings {
+		codes = append(codes, w.Code+" "+w.Message)
 	}
+	assert.Equal(t, []string{"W006 field 'total' type 'Money' references undefined DATA type"}, codes)
+
+	codes = nil
+	for _, w := range checkStructural(t, spec, StructuralConfig{BuiltinTypes: []string{"money"}}).Warnings {
+		codes = append(codes, w.Code+" "+w.Message)
+	}
+	assert.Equal(t, []string{"W006 Return type 'result' may reference undefined DATA type"}, codes,
+		"a configured list replaces the defaults")
 }
 
-func TestNormalizeTypeName(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{"User", "user"},
-		{"PolicyRule", "policyrule"},
-		{"list of User", "user"},
-		{"listofItem", "item"},
-		{"LIST_OF_THINGS", "things"},
-		{"ArrayOfUser", "user"},
-		{"setofItem", "item"},
-		{"simple", "simple"},
+func TestStructuralChecker_W006_WordsAreNotBuiltin(t *testing.T) {
+	spec := `DATA: Order
+  id: string
+
+FUNCTION: total(order: Order) → sum
+
+RULES:
+  - add up the order
+
+DONE_WHEN:
+  - total returned
+
+EXAMPLES:
+  (order) → 0
+
+ERRORS:
+  - fail`
+
+	var codes []string
+	for _, w := range checkStructural(t, spec, StructuralConfig{}).Warnings {
+		codes = append(codes, w.Code+" "+w.Message)
 	}
+	assert.Equal(t, []string{"W006 Return type 'sum' may reference undefined DATA type"}, codes)
+}
+
+func TestStructuralChecker_ExampleArguments(t *testing.T) {
+	r := checkStructural(t, `FUNCTION: add_to_cart(cart, item, qty?) → cart
+
+RULES:
+  - add qty of item to cart, one by default
+
+DONE_WHEN:
+  - cart contains the item
+
+EXAMPLES:
+  (empty_cart, apple) → [apple]
+  (empty_cart, apple, 2) → [apple, apple]
+  (empty_cart) → [apple]
+  (empty_cart, apple, 2, extra) → [apple]
+  (cart: empty, item: apple) → [apple]
+  (cart: empty, itme: apple) → [apple]
+  (empty, cart: full) → [apple]
+  (item: apple) → [apple]
+  (a cart holding three items, apple) → [apple]
+
+ERRORS:
+  - any unhandled condition → fail with descriptive message`, StructuralConfig{})
 
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := normalizeTypeName(tt.input)
-			assert.Equal(t, tt.expected, result)
-		})
+	var got []string
+	for _, e := range r.Errors {
+		got = append(got, e.Code+" ["+e.Location+"] "+e.Message)
 	}
+	assert.Equal(t, []string{
+		"E007 [FUNCTION add_to_cart EXAMPLES] example 3 passes 1 argument(s), but add_to_cart takes 2 to 3 (missing item)",
+		"E007 [FUNCTION add_to_cart EXAMPLES] example 4 passes 4 argument(s), but add_to_cart takes 2 to 3",
+		"E008 [FUNCTION add_to_cart EXAMPLES] example 6 names argument 'itme', which is not an input of add_to_cart",
+		"E008 [FUNCTION add_to_cart EXAMPLES] example 7 passes argument 'cart' more than once",
+		"E007 [FUNCTION add_to_cart EXAMPLES] example 8 passes 1 argument(s), but add_to_cart takes 2 to 3 (missing cart)",
+	}, got, "prose arguments in example 9 are not counted")
+}
+
+func TestStructuralChecker_ExampleArguments_Skipped(t *testing.T) {
+	r := checkStructural(t, `FUNCTION: sum(numbers...) → total
+
+RULES:
+  - add the numbers
+
+DONE_WHEN:
+  - total returned
+
+EXAMPLES:
+  (1, 2, 3) → 6
+  sum(1) → 1
+  "n/a" → Error: no numbers
+
+ERRORS:
+  - any unhandled condition → fail with descriptive message`, StructuralConfig{})
+
+	assert.Empty(t, r.Errors, "variadic inputs can't be counted")
 }
 
 func TestFormatFunctionLocation(t *testing.T) {
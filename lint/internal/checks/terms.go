@@ -0,0 +1,96 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A term list entry is either a phrase, matched case-insensitively on word
+// boundaries ("internal state"), or a regular expression between slashes
+// ("/\binternal(ly)? \w+/").
+
+// CompileTerms compiles term list entries into patterns.
+func CompileTerms(terms []string) ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, 0, len(terms))
+	for _, term := range terms {
+		p, err := compileTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns, nil
+}
+
+// mustCompileTerms compiles terms, skipping invalid entries. Callers
+// validate configured terms with CompileTerms before they get here.
+func mustCompileTerms(terms []string) []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+	for _, term := range terms {
+		if p, err := compileTerm(term); err == nil && p != nil {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+func compileTerm(term string) (*regexp.Regexp, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, nil
+	}
+	if len(term) > 2 && strings.HasPrefix(term, "/") && strings.HasSuffix(term, "/") {
+		p, err := regexp.Compile("(?i)" + term[1:len(term)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid term pattern %s: %w", term, err)
+		}
+		return p, nil
+	}
+	words := strings.Fields(regexp.QuoteMeta(term))
+	return regexp.MustCompile(`(?i)(^|\W)` + strings.Join(words, `\s+`) + `($|\W)`), nil
+}
+
+// findTerm returns the first phrase in text matched by any pattern, and its
+// position, or "" and nil.
+func findTerm(patterns []*regexp.Regexp, text string) (string, []int) {
+	var best []int
+	for _, p := range patterns {
+		loc := p.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		loc = trimMatch(text, loc)
+		if best == nil || loc[0] < best[0] {
+			best = loc
+		}
+	}
+	if best == nil {
+		return "", nil
+	}
+	return text[best[0]:best[1]], best
+}
+
+// trimMatch drops the boundary characters a phrase pattern matches around
+// the phrase itself.
+func trimMatch(text string, loc []int) []int {
+	start, end := loc[0], loc[1]
+	for start < end && !isWordChar(text[start]) {
+		start++
+	}
+	for end > start && !isWordChar(text[end-1]) {
+		end--
+	}
+	return []int{start, end}
+}
+
+func isWordChar(ch byte) bool {
+	return ch == '_' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= 0x80
+}
+
+// highlight marks text[loc[0]:loc[1]] with brackets, e.g. "[internal counter] reaches zero".
+func highlight(text string, loc []int) string {
+	return text[:loc[0]] + "[" + text[loc[0]:loc[1]] + "]" + text[loc[1]:]
+}
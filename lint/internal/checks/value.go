@@ -0,0 +1,237 @@
+package checks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ValueKind is the kind of a value written in an example.
+type ValueKind string
+
+// Example value kinds.
+const (
+	ValueObject ValueKind = "object"
+	ValueList   ValueKind = "list"
+	ValueString ValueKind = "string"
+	ValueNumber ValueKind = "number"
+	ValueBool   ValueKind = "boolean"
+	ValueNull   ValueKind = "null"
+	// ValueWord is a bare word or phrase such as sha256, User or item_a(qty:2):
+	// an enum value or a placeholder, so it matches most types.
+	ValueWord ValueKind = "word"
+)
+
+// Value is a parsed example value such as { success: true, user: User }.
+type Value struct {
+	Kind   ValueKind
+	Text   string        // the value as written, for scalars
+	Fields []ObjectField // for ValueObject, in order
+	Items  []Value       // for ValueList
+}
+
+// ObjectField is one "key: value" pair of an object value.
+type ObjectField struct {
+	Key   string
+	Value Value
+}
+
+// ParseValue parses an example input or output as a structured value. It
+// reports false for text that isn't one value, such as "Error: not found"
+// or prose.
+func ParseValue(text string) (Value, bool) {
+	p := &valueParser{s: strings.TrimSpace(text)}
+	v, ok := p.value()
+	if !ok {
+		return Value{}, false
+	}
+	p.skipSpace()
+	return v, p.pos == len(p.s)
+}
+
+// Argument is one argument of an example input. Name is set for a named
+// argument such as "cart: empty".
+type Argument struct {
+	Name  string
+	Value Value
+}
+
+// ParseArguments parses an example input such as "(2, 3)" or
+// "(cart: empty, item: a)" into its arguments. It reports false for input
+// that isn't a parenthesized argument list.
+func ParseArguments(input string) ([]Argument, bool) {
+	p := &valueParser{s: strings.TrimSpace(input)}
+	if p.peek() != '(' {
+		return nil, false
+	}
+	p.pos++
+
+	var args []Argument
+	for {
+		p.skipSpace()
+		if p.peek() == ')' && len(args) == 0 {
+			p.pos++
+			break
+		}
+		var arg Argument
+		if m := objectKeyPattern.FindStringSubmatch(p.s[p.pos:]); m != nil {
+			arg.Name = strings.Trim(m[1], `"'`)
+			p.pos += len(m[0])
+		}
+		v, ok := p.value()
+		if !ok {
+			return nil, false
+		}
+		arg.Value = v
+		args = append(args, arg)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+			continue
+		case ')':
+			p.pos++
+		default:
+			return nil, false
+		}
+		break
+	}
+	p.skipSpace()
+	return args, p.pos == len(p.s)
+}
+
+type valueParser struct {
+	s   string
+	pos int
+}
+
+func (p *valueParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *valueParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *valueParser) value() (Value, bool) {
+	p.skipSpace()
+	switch ch := p.peek(); ch {
+	case '{':
+		return p.object()
+	case '[':
+		return p.list()
+	case '"', '\'':
+		return p.quoted(ch)
+	case 0:
+		return Value{}, false
+	}
+	return p.scalar()
+}
+
+var objectKeyPattern = regexp.MustCompile(`^\s*("[^"]*"|'[^']*'|[A-Za-z_][\w-]*)\s*:`)
+
+func (p *valueParser) object() (Value, bool) {
+	p.pos++ // {
+	v := Value{Kind: ValueObject}
+	for {
+		p.skipSpace()
+		if p.peek() == '}' {
+			p.pos++
+			return v, true
+		}
+		m := objectKeyPattern.FindStringSubmatch(p.s[p.pos:])
+		if m == nil {
+			return Value{}, false
+		}
+		p.pos += len(m[0])
+		field, ok := p.value()
+		if !ok {
+			return Value{}, false
+		}
+		v.Fields = append(v.Fields, ObjectField{Key: strings.Trim(m[1], `"'`), Value: field})
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+		default:
+			return Value{}, false
+		}
+	}
+}
+
+func (p *valueParser) list() (Value, bool) {
+	p.pos++ // [
+	v := Value{Kind: ValueList}
+	for {
+		p.skipSpace()
+		if p.peek() == ']' {
+			p.pos++
+			return v, true
+		}
+		item, ok := p.value()
+		if !ok {
+			return Value{}, false
+		}
+		v.Items = append(v.Items, item)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return Value{}, false
+		}
+	}
+}
+
+func (p *valueParser) quoted(quote byte) (Value, bool) {
+	end := strings.IndexByte(p.s[p.pos+1:], quote)
+	if end < 0 {
+		return Value{}, false
+	}
+	text := p.s[p.pos : p.pos+end+2]
+	p.pos += end + 2
+	return Value{Kind: ValueString, Text: text}, true
+}
+
+var numberPattern = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][-+]?\d+)?$`)
+
+// scalar reads up to the next delimiter at this nesting level. Parentheses
+// are kept together so that item_a(qty: 2) stays one word; an unmatched
+// closing one ends the value.
+func (p *valueParser) scalar() (Value, bool) {
+	start, depth := p.pos, 0
+	for ; p.pos < len(p.s); p.pos++ {
+		ch := p.s[p.pos]
+		if ch == '(' {
+			depth++
+		} else if ch == ')' && depth > 0 {
+			depth--
+		} else if depth == 0 && (ch == ',' || ch == '}' || ch == ']' || ch == ':' || ch == ')') {
+			break
+		}
+	}
+	text := strings.TrimSpace(p.s[start:p.pos])
+	if text == "" || p.peek() == ':' {
+		return Value{}, false
+	}
+
+	switch strings.ToLower(text) {
+	case "true", "false":
+		return Value{Kind: ValueBool, Text: text}, true
+	case "null", "nil", "none":
+		return Value{Kind: ValueNull, Text: text}, true
+	}
+	if numberPattern.MatchString(text) {
+		return Value{Kind: ValueNumber, Text: text}, true
+	}
+	return Value{Kind: ValueWord, Text: text}, true
+}
@@ -0,0 +1,77 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValue_Object(t *testing.T) {
+	v, ok := ParseValue(`{ success: true, user: User, tags: ["a", "b"], total: 12.5, note: null }`)
+	require.True(t, ok)
+	require.Equal(t, ValueObject, v.Kind)
+	require.Len(t, v.Fields, 5)
+
+	kinds := map[string]ValueKind{}
+	for _, f := range v.Fields {
+		kinds[f.Key] = f.Value.Kind
+	}
+	assert.Equal(t, map[string]ValueKind{
+		"success": ValueBool,
+		"user":    ValueWord,
+		"tags":    ValueList,
+		"total":   ValueNumber,
+		"note":    ValueNull,
+	}, kinds)
+	assert.Len(t, v.Fields[2].Value.Items, 2)
+}
+
+func TestParseValue_Scalars(t *testing.T) {
+	tests := []struct {
+		in   string
+		kind ValueKind
+	}{
+		{`"hello"`, ValueString},
+		{`-3`, ValueNumber},
+		{`false`, ValueBool},
+		{`item_a(qty: 2)`, ValueWord},
+	}
+	for _, tt := range tests {
+		v, ok := ParseValue(tt.in)
+		require.True(t, ok, tt.in)
+		assert.Equal(t, tt.kind, v.Kind, tt.in)
+	}
+}
+
+func TestParseValue_NotAValue(t *testing.T) {
+	for _, in := range []string{"Error: not found", `{ a: 1`, `{ a: 1 } and more`, ""} {
+		_, ok := ParseValue(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestParseArguments(t *testing.T) {
+	args, ok := ParseArguments(`(2, "a, b", [1, 2], item_a(qty: 2))`)
+	require.True(t, ok)
+	require.Len(t, args, 4)
+	assert.Equal(t, ValueNumber, args[0].Value.Kind)
+	assert.Equal(t, `"a, b"`, args[1].Value.Text)
+	assert.Equal(t, ValueList, args[2].Value.Kind)
+	assert.Equal(t, "item_a(qty: 2)", args[3].Value.Text)
+
+	args, ok = ParseArguments("(cart: empty, item: { sku: 1 })")
+	require.True(t, ok)
+	assert.Equal(t, "cart", args[0].Name)
+	assert.Equal(t, "item", args[1].Name)
+	assert.Equal(t, ValueObject, args[1].Value.Kind)
+
+	args, ok = ParseArguments("()")
+	assert.True(t, ok)
+	assert.Empty(t, args)
+
+	for _, input := range []string{`"hello"`, "(a, b", "(a,)", "(a) and more"} {
+		_, ok := ParseArguments(input)
+		assert.False(t, ok, input)
+	}
+}
@@ -0,0 +1,388 @@
+// Package config loads project configuration for simplex-lint from
+// .simplex-lint.yaml files discovered alongside the specs being linted.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thinkwright/simplex/lint/internal/checks"
+	"github.com/thinkwright/simplex/lint/internal/preset"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// FileNames are the config file names looked for in each directory,
+// in order of preference.
+var FileNames = []string{".simplex-lint.yaml", ".simplex-lint.yml"}
+
+// Spec versions the linter knows how to check.
+const (
+	SpecVersion03 = "0.3"
+	SpecVersion04 = "0.4"
+	SpecVersion05 = "0.5"
+
+	// DefaultSpecVersion is used when no version is configured.
+	DefaultSpecVersion = SpecVersion05
+)
+
+// SpecVersions lists the supported spec versions, oldest first.
+var SpecVersions = []string{SpecVersion03, SpecVersion04, SpecVersion05}
+
+// Thresholds mirrors checks.ComplexityConfig. Zero means "use the default".
+type Thresholds struct {
+	MaxRules      int `yaml:"max_rules"`
+	MaxInputs     int `yaml:"max_inputs"`
+	MaxRuleLength int `yaml:"max_rule_length"`
+	MaxFunctions  int `yaml:"max_functions"`
+	MaxEvalK      int `yaml:"max_eval_k"`
+}
+
+// Terms mirrors lint.Terms. A list left out keeps the built-in terms.
+type Terms struct {
+	NotObservable []string `yaml:"not_observable"`
+	Vague         []string `yaml:"vague"`
+	Observable    []string `yaml:"observable"`
+	Procedural    []string `yaml:"procedural"`
+	CatchAll      []string `yaml:"catch_all"`
+}
+
+// DataFlow mirrors lint.DataFlow. Multi checks the graph across every
+// input file, like --multi.
+type DataFlow struct {
+	External []string `yaml:"external"`
+	Multi    bool     `yaml:"multi"`
+}
+
+// LLM holds the semantic check provider settings.
+type LLM struct {
+	Provider string `yaml:"provider"`
+	Model    string `yaml:"model"`
+}
+
+// Plugin configures an external checker executable. Relative commands are
+// resolved against the directory of the config file.
+type Plugin struct {
+	Name    string   `yaml:"name"`
+	Command []string `yaml:"command"`
+	Timeout string   `yaml:"timeout"` // Go duration, e.g. "5s"
+}
+
+// TimeoutDuration returns the plugin timeout, zero when not configured.
+func (p Plugin) TimeoutDuration() time.Duration {
+	d, _ := time.ParseDuration(p.Timeout)
+	return d
+}
+
+// Config is the contents of a .simplex-lint.yaml file.
+//
+// Example:
+//
+//	preset: recommended
+//	status_presets:
+//	  release: strict
+//	spec_version: "0.5"
+//	thresholds:
+//	  max_rules: 20
+//	  max_inputs: 8
+//	disable: [W011]
+//	severity:
+//	  E012: warning
+//	  W011: info
+//	terms:
+//	  vague: [processed, handled, '/\bgoes well\b/']
+//	builtin_types: [result, Money]
+//	data_flow:
+//	  external: [env., Database.]
+//	  multi: true
+//	fail_on: warning
+//	max_warnings: 10
+//	llm:
+//	  provider: anthropic
+//	plugins:
+//	  - name: payments
+//	    command: ["./lint/payments.py"]
+//	    timeout: 5s
+type Config struct {
+	Preset        string            `yaml:"preset"`
+	StatusPresets map[string]string `yaml:"status_presets"` // front-matter status → preset
+	SpecVersion   string            `yaml:"spec_version"`
+	Thresholds    Thresholds        `yaml:"thresholds"`
+	Enable        []string          `yaml:"enable"`
+	Disable       []string          `yaml:"disable"`
+	Severity      map[string]string `yaml:"severity"`
+	Terms         Terms             `yaml:"terms"`
+	BuiltinTypes  []string          `yaml:"builtin_types"` // replaces the default builtin type names
+	DataFlow      DataFlow          `yaml:"data_flow"`
+	FailOn        string            `yaml:"fail_on"`
+	MaxWarnings   *int              `yaml:"max_warnings"`
+	LLM           LLM               `yaml:"llm"`
+	Plugins       []Plugin          `yaml:"plugins"`
+
+	// Path is the file the config was loaded from, empty for defaults.
+	Path string `yaml:"-"`
+}
+
+// Load reads and validates the config file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	cfg.Path = path
+	return cfg, nil
+}
+
+// Parse decodes and validates config file contents. Unknown keys are rejected
+// so that typos don't silently fall back to defaults.
+func Parse(data []byte) (*Config, error) {
+	cfg := &Config{}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// validate checks field values and normalizes rule codes to upper case.
+func (c *Config) validate() error {
+	if c.SpecVersion != "" && !IsSpecVersion(c.SpecVersion) {
+		return fmt.Errorf("spec_version must be one of %s, got: %s",
+			strings.Join(SpecVersions, ", "), c.SpecVersion)
+	}
+
+	if c.Preset != "" {
+		if err := ValidatePreset(c.Preset); err != nil {
+			return err
+		}
+	}
+	for status, name := range c.StatusPresets {
+		if err := ValidatePreset(name); err != nil {
+			return fmt.Errorf("status_presets %s: %w", status, err)
+		}
+	}
+
+	if err := ValidateTerms(c.Terms); err != nil {
+		return err
+	}
+
+	t := c.Thresholds
+	if t.MaxRules < 0 || t.MaxInputs < 0 || t.MaxRuleLength < 0 || t.MaxFunctions < 0 || t.MaxEvalK < 0 {
+		return fmt.Errorf("thresholds must not be negative")
+	}
+
+	for i, code := range c.Enable {
+		c.Enable[i] = strings.ToUpper(strings.TrimSpace(code))
+	}
+	for i, code := range c.Disable {
+		c.Disable[i] = strings.ToUpper(strings.TrimSpace(code))
+	}
+
+	severity := make(map[string]string, len(c.Severity))
+	for code, s := range c.Severity {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !result.IsSeverity(s) {
+			return fmt.Errorf("severity for %s must be error, warning, info or hint, got: %s", code, s)
+		}
+		severity[strings.ToUpper(strings.TrimSpace(code))] = s
+	}
+	c.Severity = severity
+
+	if c.FailOn != "" {
+		c.FailOn = strings.ToLower(strings.TrimSpace(c.FailOn))
+		if err := ValidateFailOn(c.FailOn); err != nil {
+			return err
+		}
+	}
+
+	for i, p := range c.Plugins {
+		if p.Name == "" {
+			return fmt.Errorf("plugin %d has no name", i+1)
+		}
+		if len(p.Command) == 0 || p.Command[0] == "" {
+			return fmt.Errorf("plugin %s has no command", p.Name)
+		}
+		if p.Timeout != "" {
+			if d, err := time.ParseDuration(p.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("plugin %s timeout must be a positive duration such as 5s, got: %s", p.Name, p.Timeout)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Dir returns the directory the config was loaded from, or "." for defaults.
+func (c *Config) Dir() string {
+	if c.Path == "" {
+		return "."
+	}
+	return filepath.Dir(c.Path)
+}
+
+// ValidateTerms checks that every term list entry compiles, reporting the
+// first invalid one with its list name.
+func ValidateTerms(t Terms) error {
+	for _, list := range []struct {
+		name  string
+		terms []string
+	}{
+		{"not_observable", t.NotObservable},
+		{"vague", t.Vague},
+		{"observable", t.Observable},
+		{"procedural", t.Procedural},
+		{"catch_all", t.CatchAll},
+	} {
+		if _, err := checks.CompileTerms(list.terms); err != nil {
+			return fmt.Errorf("terms %s: %w", list.name, err)
+		}
+	}
+	return nil
+}
+
+// ValidatePreset checks a preset / --preset value.
+func ValidatePreset(name string) error {
+	if _, ok := preset.Get(name); !ok {
+		return fmt.Errorf("preset must be one of %s, got: %s", strings.Join(preset.Names(), ", "), name)
+	}
+	return nil
+}
+
+// ValidateFailOn checks a fail_on / --fail-on value.
+func ValidateFailOn(failOn string) error {
+	switch failOn {
+	case result.SeverityError, result.SeverityWarning, result.SeverityInfo:
+		return nil
+	}
+	return fmt.Errorf("fail_on must be error, warning or info, got: %s", failOn)
+}
+
+// FailPolicy returns the configured exit-code policy, defaulting to failing
+// on errors only with no warning budget.
+func (c *Config) FailPolicy() result.FailPolicy {
+	policy := result.DefaultFailPolicy()
+	if c.FailOn != "" {
+		policy.FailOn = c.FailOn
+	}
+	if c.MaxWarnings != nil {
+		policy.MaxWarnings = *c.MaxWarnings
+	}
+	return policy
+}
+
+// DisabledRules returns the set of rule codes turned off by the config.
+// A code listed in both enable and disable stays enabled.
+func (c *Config) DisabledRules() map[string]bool {
+	disabled := make(map[string]bool)
+	for _, code := range c.Disable {
+		disabled[code] = true
+	}
+	for _, code := range c.Enable {
+		delete(disabled, code)
+	}
+	return disabled
+}
+
+// Discover walks up from dir looking for a config file and returns the path
+// of the first one found, or "" if none exists up to the filesystem root.
+func Discover(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Loader discovers and loads config files, caching each file so that specs
+// sharing a directory tree share one parsed config.
+type Loader struct {
+	cache    map[string]*Config
+	defaults *Config
+}
+
+// NewLoader creates a new Loader.
+func NewLoader() *Loader {
+	return &Loader{cache: make(map[string]*Config), defaults: &Config{}}
+}
+
+// ForDir returns the config that applies to files in dir. When no config
+// file is found, an empty Config with defaults is returned.
+func (l *Loader) ForDir(dir string) (*Config, error) {
+	path, err := Discover(dir)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return l.defaults, nil
+	}
+	return l.Load(path)
+}
+
+// Load returns the config at path, reading it at most once.
+func (l *Loader) Load(path string) (*Config, error) {
+	if cfg, ok := l.cache[path]; ok {
+		return cfg, nil
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	l.cache[path] = cfg
+	return cfg, nil
+}
+
+// IsSpecVersion reports whether v is a supported spec version.
+func IsSpecVersion(v string) bool {
+	for _, known := range SpecVersions {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// AtLeast reports whether spec version v is the same as or newer than min.
+func AtLeast(v, min string) bool {
+	vi, mi := -1, -1
+	for i, known := range SpecVersions {
+		if known == v {
+			vi = i
+		}
+		if known == min {
+			mi = i
+		}
+	}
+	return vi >= mi
+}
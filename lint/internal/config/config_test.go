@@ -0,0 +1,254 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestParse_FullConfig(t *testing.T) {
+	cfg, err := Parse([]byte(`
+spec_version: "0.4"
+thresholds:
+  max_rules: 20
+  max_inputs: 8
+  max_rule_length: 250
+  max_functions: 12
+  max_eval_k: 10
+builtin_types: [result, Money]
+data_flow:
+  external: [env., Database.]
+  multi: true
+enable: [w010]
+disable: [W011, W010]
+severity:
+  e012: Warning
+llm:
+  provider: anthropic
+  model: claude-sonnet
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.4", cfg.SpecVersion)
+	assert.Equal(t, Thresholds{MaxRules: 20, MaxInputs: 8, MaxRuleLength: 250, MaxFunctions: 12, MaxEvalK: 10}, cfg.Thresholds)
+	assert.Equal(t, []string{"env.", "Database."}, cfg.DataFlow.External)
+	assert.True(t, cfg.DataFlow.Multi)
+	assert.Equal(t, []string{"result", "Money"}, cfg.BuiltinTypes)
+	assert.Equal(t, map[string]bool{"W011": true}, cfg.DisabledRules())
+	assert.Equal(t, map[string]string{"E012": "warning"}, cfg.Severity)
+	assert.Equal(t, "anthropic", cfg.LLM.Provider)
+	assert.Equal(t, "claude-sonnet", cfg.LLM.Model)
+}
+
+func TestParse_Empty(t *testing.T) {
+	cfg, err := Parse([]byte(""))
+	require.NoError(t, err)
+
+	assert.Empty(t, cfg.SpecVersion, "the preset or default decides")
+	assert.Empty(t, cfg.DisabledRules())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown key", "max_rules: 20", "max_rules"},
+		{"bad version", `spec_version: "0.9"`, "spec_version"},
+		{"negative threshold", "thresholds:\n  max_inputs: -1", "negative"},
+		{"negative max_eval_k", "thresholds:\n  max_eval_k: -5", "negative"},
+		{"bad severity", "severity:\n  E012: fatal", "E012"},
+		{"malformed yaml", "thresholds: [", "yaml"},
+		{"unknown preset", "preset: lenient", "preset"},
+		{"unknown status preset", "status_presets:\n  final: lenient", "final"},
+		{"bad term pattern", "terms:\n  vague: ['/(unclosed/']", "terms vague"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParse_Presets(t *testing.T) {
+	cfg, err := Parse([]byte("preset: strict\nstatus_presets:\n  draft: recommended\n  imported: compat-v0.3"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "strict", cfg.Preset)
+	assert.Equal(t, map[string]string{"draft": "recommended", "imported": "compat-v0.3"}, cfg.StatusPresets)
+}
+
+func TestParse_Terms(t *testing.T) {
+	cfg, err := Parse([]byte("terms:\n  vague: [handled, '/\\bgoes well\\b/']\n  observable: []\n  procedural: [first]\n  catch_all: [everything else]"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"handled", `/\bgoes well\b/`}, cfg.Terms.Vague)
+	assert.NotNil(t, cfg.Terms.Observable, "an empty list turns the defaults off")
+	assert.Nil(t, cfg.Terms.NotObservable, "a missing list keeps the defaults")
+	assert.Equal(t, []string{"first"}, cfg.Terms.Procedural)
+	assert.Equal(t, []string{"everything else"}, cfg.Terms.CatchAll)
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "nope.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read config")
+}
+
+func TestLoad_InvalidContentNamesPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".simplex-lint.yaml")
+	writeFile(t, path, "bogus: true")
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+}
+
+func TestDiscover_WalksUp(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".simplex-lint.yaml"), "thresholds:\n  max_rules: 20")
+	nested := filepath.Join(root, "specs", "auth")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+
+	path, err := Discover(nested)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, ".simplex-lint.yaml"), path)
+}
+
+func TestDiscover_NearestWins(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".simplex-lint.yaml"), "")
+	writeFile(t, filepath.Join(root, "specs", ".simplex-lint.yml"), "")
+
+	path, err := Discover(filepath.Join(root, "specs"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "specs", ".simplex-lint.yml"), path)
+}
+
+func TestDiscover_NotFound(t *testing.T) {
+	path, err := Discover(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "", path)
+}
+
+func TestLoader_CachesByPath(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".simplex-lint.yaml"), "thresholds:\n  max_rules: 20")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "a"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "b"), 0o755))
+
+	loader := NewLoader()
+	a, err := loader.ForDir(filepath.Join(root, "a"))
+	require.NoError(t, err)
+	b, err := loader.ForDir(filepath.Join(root, "b"))
+	require.NoError(t, err)
+
+	assert.Same(t, a, b)
+	assert.Equal(t, 20, a.Thresholds.MaxRules)
+	assert.Equal(t, filepath.Join(root, ".simplex-lint.yaml"), a.Path)
+}
+
+func TestLoader_NoConfig(t *testing.T) {
+	cfg, err := NewLoader().ForDir(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "", cfg.Path)
+	assert.Empty(t, cfg.SpecVersion)
+}
+
+func TestLoader_InvalidConfig(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".simplex-lint.yaml"), "spec_version: nope")
+
+	_, err := NewLoader().ForDir(root)
+	assert.Error(t, err)
+}
+
+func TestAtLeast(t *testing.T) {
+	assert.True(t, AtLeast("0.5", "0.4"))
+	assert.True(t, AtLeast("0.4", "0.4"))
+	assert.False(t, AtLeast("0.3", "0.4"))
+}
+
+func TestLoader_NoConfigSharesDefaults(t *testing.T) {
+	loader := NewLoader()
+	a, err := loader.ForDir(t.TempDir())
+	require.NoError(t, err)
+	b, err := loader.ForDir(t.TempDir())
+	require.NoError(t, err)
+	assert.Same(t, a, b)
+}
+
+func TestParse_FailPolicy(t *testing.T) {
+	cfg, err := Parse([]byte("fail_on: Warning\nmax_warnings: 0\nseverity:\n  W011: info\n  W010: hint"))
+	require.NoError(t, err)
+
+	policy := cfg.FailPolicy()
+	assert.Equal(t, "warning", policy.FailOn)
+	assert.Equal(t, 0, policy.MaxWarnings)
+	assert.Equal(t, map[string]string{"W011": "info", "W010": "hint"}, cfg.Severity)
+}
+
+func TestParse_DefaultFailPolicy(t *testing.T) {
+	cfg, err := Parse([]byte(""))
+	require.NoError(t, err)
+
+	policy := cfg.FailPolicy()
+	assert.Equal(t, "error", policy.FailOn)
+	assert.Equal(t, -1, policy.MaxWarnings)
+}
+
+func TestValidateFailOn(t *testing.T) {
+	assert.NoError(t, ValidateFailOn("info"))
+	assert.Error(t, ValidateFailOn("hint"))
+
+	_, err := Parse([]byte("fail_on: never"))
+	assert.Error(t, err)
+}
+
+func TestParse_Plugins(t *testing.T) {
+	cfg, err := Parse([]byte(`
+plugins:
+  - name: payments
+    command: ["./plugins/payments.py", "--strict"]
+    timeout: 5s
+  - name: naming
+    command: [naming-lint]
+`))
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Plugins, 2)
+	assert.Equal(t, []string{"./plugins/payments.py", "--strict"}, cfg.Plugins[0].Command)
+	assert.Equal(t, 5*time.Second, cfg.Plugins[0].TimeoutDuration())
+	assert.Zero(t, cfg.Plugins[1].TimeoutDuration())
+}
+
+func TestParse_InvalidPlugins(t *testing.T) {
+	for _, content := range []string{
+		"plugins:\n  - command: [x]",
+		"plugins:\n  - name: x",
+		"plugins:\n  - name: x\n    command: [x]\n    timeout: soon",
+		"plugins:\n  - name: x\n    command: [x]\n    timeout: -1s",
+	} {
+		_, err := Parse([]byte(content))
+		assert.Error(t, err, content)
+	}
+}
+
+func TestConfig_Dir(t *testing.T) {
+	assert.Equal(t, ".", (&Config{}).Dir())
+	assert.Equal(t, filepath.Join("a", "b"), (&Config{Path: filepath.Join("a", "b", ".simplex-lint.yaml")}).Dir())
+}
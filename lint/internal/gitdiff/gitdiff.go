@@ -0,0 +1,176 @@
+// Package gitdiff limits lint results to the functions a change touched. It
+// runs git diff against a ref in the local repository, maps the changed line
+// ranges onto FUNCTION blocks and drops issues reported against functions the
+// diff did not touch. Spec-level issues are always kept.
+package gitdiff
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// LineRange is an inclusive, 1-based range of lines in the current file.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Changes describes which lines of a file differ from the ref.
+type Changes struct {
+	All    bool // the file is new or untracked; every line counts as changed
+	Ranges []LineRange
+}
+
+// Touches reports whether any changed line falls within [start, end].
+func (c *Changes) Touches(start, end int) bool {
+	if c.All {
+		return true
+	}
+	for _, r := range c.Ranges {
+		if r.Start <= end && start <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangedLines returns the lines of path that differ from ref, including
+// uncommitted changes in the working tree. A ref starting with "-" is
+// rejected, since git would read it as an option.
+func ChangedLines(ref, path string) (*Changes, error) {
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid ref %q: must not start with '-'", ref)
+	}
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	out, err := git(dir, "diff", "--no-color", "--no-ext-diff", "--unified=0", ref, "--", name)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := parseHunks(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse git diff for %s: %w", path, err)
+	}
+	if len(ranges) > 0 {
+		return &Changes{Ranges: ranges}, nil
+	}
+
+	// No diff: either unchanged or not tracked by git at all
+	if _, err := git(dir, "ls-files", "--error-unmatch", "--", name); err != nil {
+		return &Changes{All: true}, nil
+	}
+	return &Changes{}, nil
+}
+
+// git runs a git command in dir and returns its stdout.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
+
+// hunkPattern matches a unified diff hunk header, capturing the new-file
+// start line and optional line count.
+var hunkPattern = regexp.MustCompile(`(?m)^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// parseHunks extracts the changed line ranges of the new file from
+// git diff --unified=0 output. A pure deletion touches the lines on either
+// side of it.
+func parseHunks(diff string) ([]LineRange, error) {
+	var ranges []LineRange
+	for _, m := range hunkPattern.FindAllStringSubmatch(diff, -1) {
+		start, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, err
+		}
+		count := 1
+		if m[2] != "" {
+			if count, err = strconv.Atoi(m[2]); err != nil {
+				return nil, err
+			}
+		}
+
+		if count == 0 {
+			ranges = append(ranges, LineRange{Start: max(start, 1), End: start + 1})
+			continue
+		}
+		ranges = append(ranges, LineRange{Start: start, End: start + count - 1})
+	}
+	return ranges, nil
+}
+
+// ChangedFunctions returns the names of the functions whose lines the
+// changes touch, using the same "(unnamed)" naming as issue locations.
+func ChangedFunctions(spec *parser.ParsedSpec, c *Changes) map[string]bool {
+	changed := make(map[string]bool)
+	for _, fn := range spec.Functions {
+		if c.Touches(fn.LineNumber, fn.EndLine) {
+			changed[functionName(fn.Name)] = true
+		}
+	}
+	return changed
+}
+
+// Filter removes issues reported against functions the changes don't touch
+// and counts them in r.Stats.Unchanged. Issues not located in a function,
+// such as spec-level W011, are kept, as are issues whose related functions,
+// such as the rest of a trigger cycle, changed.
+func Filter(spec *parser.ParsedSpec, c *Changes, r *result.LintResult) {
+	if c.All {
+		return
+	}
+
+	changed := ChangedFunctions(spec, c)
+
+	var kept []result.LintError
+	for _, e := range r.Issues() {
+		function, _ := result.ParseLocation(e.Location)
+		if function != "" && !changed[function] && !anyChanged(e.Related, changed) {
+			r.Stats.Unchanged++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	r.SetIssues(kept)
+}
+
+// anyChanged reports whether any of the functions changed.
+func anyChanged(functions []string, changed map[string]bool) bool {
+	for _, f := range functions {
+		if changed[functionName(f)] {
+			return true
+		}
+	}
+	return false
+}
+
+// functionName mirrors the checkers' location naming for unnamed functions.
+func functionName(name string) string {
+	if name == "" {
+		return "(unnamed)"
+	}
+	return name
+}
@@ -0,0 +1,179 @@
+package gitdiff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+const twoFunctions = `FUNCTION: first(a) → result
+
+RULES:
+  - return a
+
+EXAMPLES:
+  (1) → 1
+
+FUNCTION: second(a) → result
+
+RULES:
+  - return a
+
+EXAMPLES:
+  (1) → 1
+`
+
+func TestParseHunks(t *testing.T) {
+	diff := `diff --git a/spec.md b/spec.md
+--- a/spec.md
++++ b/spec.md
+@@ -3 +3 @@ RULES:
+-  - return b
++  - return a
+@@ -10,0 +11,2 @@ FUNCTION: second(a) → result
++  - new rule
++  - another
+@@ -20,3 +21,0 @@
+-gone
+`
+	ranges, err := parseHunks(diff)
+	require.NoError(t, err)
+
+	assert.Equal(t, []LineRange{
+		{Start: 3, End: 3},
+		{Start: 11, End: 12},
+		{Start: 21, End: 22},
+	}, ranges)
+}
+
+func TestChanges_Touches(t *testing.T) {
+	c := &Changes{Ranges: []LineRange{{Start: 5, End: 6}}}
+
+	assert.True(t, c.Touches(1, 5))
+	assert.True(t, c.Touches(6, 10))
+	assert.False(t, c.Touches(7, 10))
+	assert.True(t, (&Changes{All: true}).Touches(100, 200))
+	assert.False(t, (&Changes{}).Touches(1, 100))
+}
+
+func TestFilter_KeepsChangedFunctionsAndSpecLevelIssues(t *testing.T) {
+	spec := parser.NewParser().Parse(twoFunctions)
+	r := result.NewLintResult("spec.md")
+	r.AddError("E003", "missing DONE_WHEN", "FUNCTION first")
+	r.AddError("E003", "missing DONE_WHEN", "FUNCTION second")
+	r.AddWarning("W010", "long rule", "FUNCTION second RULES")
+	r.AddWarning("W011", "many functions", "spec")
+
+	Filter(spec, &Changes{Ranges: []LineRange{{Start: 12, End: 12}}}, r)
+
+	var got []string
+	for _, e := range r.Issues() {
+		got = append(got, e.Code+"@"+e.Location)
+	}
+	assert.Equal(t, []string{"E003@FUNCTION second", "W010@FUNCTION second RULES", "W011@spec"}, got)
+	assert.Equal(t, 1, r.Stats.Unchanged)
+}
+
+func TestFilter_KeepsCycleThroughChangedFunction(t *testing.T) {
+	spec := parser.NewParser().Parse(twoFunctions)
+	r := result.NewLintResult("spec.md")
+	r.AddError("E102", "trigger cycle: first → second → first (via a, b)", "FUNCTION first TRIGGERS")
+	r.Errors[0].Related = []string{"second"}
+
+	Filter(spec, &Changes{Ranges: []LineRange{{Start: 12, End: 12}}}, r)
+
+	assert.Len(t, r.Errors, 1, "second changed, so the cycle through it is kept")
+	assert.Zero(t, r.Stats.Unchanged)
+}
+
+func TestFilter_AllChangedKeepsEverything(t *testing.T) {
+	spec := parser.NewParser().Parse(twoFunctions)
+	r := result.NewLintResult("spec.md")
+	r.AddError("E003", "missing DONE_WHEN", "FUNCTION first")
+
+	Filter(spec, &Changes{All: true}, r)
+
+	assert.Len(t, r.Errors, 1)
+}
+
+// gitRepo creates a repository in a temp dir with spec.md committed.
+func gitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	run("init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.md"), []byte(twoFunctions), 0o644))
+	run("add", "spec.md")
+	run("commit", "-q", "-m", "initial")
+	return dir
+}
+
+func TestChangedLines_WorkingTreeEdit(t *testing.T) {
+	dir := gitRepo(t)
+	path := filepath.Join(dir, "spec.md")
+	edited := strings.Replace(twoFunctions, "FUNCTION: second(a) → result\n\nRULES:\n  - return a",
+		"FUNCTION: second(a) → result\n\nRULES:\n  - return a doubled", 1)
+	require.NoError(t, os.WriteFile(path, []byte(edited), 0o644))
+
+	c, err := ChangedLines("HEAD", path)
+	require.NoError(t, err)
+
+	assert.False(t, c.All)
+	assert.Equal(t, []LineRange{{Start: 12, End: 12}}, c.Ranges)
+	assert.Equal(t, map[string]bool{"second": true}, ChangedFunctions(parser.NewParser().Parse(edited), c))
+}
+
+func TestChangedLines_Unchanged(t *testing.T) {
+	dir := gitRepo(t)
+
+	c, err := ChangedLines("HEAD", filepath.Join(dir, "spec.md"))
+	require.NoError(t, err)
+
+	assert.False(t, c.All)
+	assert.Empty(t, c.Ranges)
+}
+
+func TestChangedLines_UntrackedFile(t *testing.T) {
+	dir := gitRepo(t)
+	path := filepath.Join(dir, "new.md")
+	require.NoError(t, os.WriteFile(path, []byte(twoFunctions), 0o644))
+
+	c, err := ChangedLines("HEAD", path)
+	require.NoError(t, err)
+
+	assert.True(t, c.All)
+}
+
+func TestChangedLines_UnknownRef(t *testing.T) {
+	dir := gitRepo(t)
+
+	_, err := ChangedLines("no-such-ref", filepath.Join(dir, "spec.md"))
+	assert.Error(t, err)
+}
+
+func TestChangedLines_OptionLikeRef(t *testing.T) {
+	dir := gitRepo(t)
+	out := filepath.Join(t.TempDir(), "out")
+
+	_, err := ChangedLines("--output="+out, filepath.Join(dir, "spec.md"))
+	assert.ErrorContains(t, err, "must not start with '-'")
+	assert.NoFileExists(t, out)
+}
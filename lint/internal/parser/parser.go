@@ -68,28 +68,39 @@ var RequiredFunctionLandmarks = map[string]bool{
 
 // Landmark represents a parsed landmark block.
 type Landmark struct {
-	Name       string // e.g., "FUNCTION", "RULES"
-	Content    string // raw content after the landmark declaration
-	LineNumber int    // 1-based line number where landmark starts
+	Name       string `json:"name"`        // e.g., "FUNCTION", "RULES"
+	Content    string `json:"content"`     // raw content after the landmark declaration
+	LineNumber int    `json:"line_number"` // 1-based line number where landmark starts
+	EndLine    int    `json:"end_line"`    // 1-based line number of the last line before the next landmark
 }
 
 // FunctionBlock represents a parsed FUNCTION with its nested landmarks.
 type FunctionBlock struct {
-	Signature  string              // e.g., "filter_policies(policies, ids, tags) → filtered list"
-	Name       string              // e.g., "filter_policies"
-	Inputs     []string            // e.g., ["policies", "ids", "tags"]
-	ReturnType string              // e.g., "filtered list"
-	Landmarks  map[string]Landmark // nested landmarks (RULES, DONE_WHEN, etc.)
-	LineNumber int                 // 1-based line number where FUNCTION starts
+	Signature  string              `json:"signature"`   // e.g., "filter_policies(policies, ids, tags) → filtered list"
+	Name       string              `json:"name"`        // e.g., "filter_policies"
+	Inputs     []string            `json:"inputs"`      // e.g., ["policies", "ids", "tags"]
+	ReturnType string              `json:"return_type"` // e.g., "filtered list"
+	Landmarks  map[string]Landmark `json:"landmarks"`   // nested landmarks (RULES, DONE_WHEN, etc.)
+	LineNumber int                 `json:"line_number"` // 1-based line number where FUNCTION starts
+	EndLine    int                 `json:"end_line"`    // 1-based line number where the FUNCTION block ends
+}
+
+// Directive is a "simplex-lint:" comment line. Directives are lint metadata,
+// so their lines are removed from landmark content.
+type Directive struct {
+	Text       string `json:"text"`        // text after "simplex-lint:", e.g. "ignore E011 -- legacy API"
+	LineNumber int    `json:"line_number"` // 1-based line number of the directive
 }
 
 // ParsedSpec represents the fully parsed specification.
 type ParsedSpec struct {
-	Functions     []FunctionBlock
-	DataBlocks    []Landmark
-	Constraints   []Landmark
-	RawText       string
-	ParseWarnings []string // non-fatal parse issues
+	Functions     []FunctionBlock   `json:"functions"`
+	DataBlocks    []Landmark        `json:"data_blocks"`
+	Constraints   []Landmark        `json:"constraints"`
+	Directives    []Directive       `json:"directives"`
+	FrontMatter   map[string]string `json:"front_matter,omitempty"` // "---" delimited key: value header, lower-case keys
+	RawText       string            `json:"raw_text"`
+	ParseWarnings []string          `json:"parse_warnings"` // non-fatal parse issues
 }
 
 // landmarkMatch represents a regex match for a landmark.
@@ -107,6 +118,8 @@ type Parser struct {
 	landmarkPattern *regexp.Regexp
 	// functionSigPattern extracts function name, inputs, and return type
 	functionSigPattern *regexp.Regexp
+	// directivePattern matches "simplex-lint:" comment lines
+	directivePattern *regexp.Regexp
 }
 
 // NewParser creates a new Parser instance.
@@ -118,6 +131,9 @@ func NewParser() *Parser {
 		// Match function signature: name(args) → return_type
 		// Handles both → and -> for arrow
 		functionSigPattern: regexp.MustCompile(`^(\w+)\s*\(([^)]*)\)\s*(?:→|->)\s*(.+)$`),
+		// Match directive comments: optional #, // or <!-- marker, then "simplex-lint:"
+		// Captures: (1) directive text, without a closing -->
+		directivePattern: regexp.MustCompile(`^\s*(?:#|//|<!--)?\s*simplex-lint:\s*(.*?)\s*(?:-->)?\s*$`),
 	}
 }
 
@@ -127,6 +143,8 @@ func (p *Parser) Parse(text string) *ParsedSpec {
 		Functions:     []FunctionBlock{},
 		DataBlocks:    []Landmark{},
 		Constraints:   []Landmark{},
+		Directives:    p.findDirectives(text),
+		FrontMatter:   parseFrontMatter(text),
 		RawText:       text,
 		ParseWarnings: []string{},
 	}
@@ -146,6 +164,34 @@ func (p *Parser) Parse(text string) *ParsedSpec {
 	return spec
 }
 
+// parseFrontMatter reads a "---" delimited header of key: value lines at the
+// top of the spec, such as "status: release". Nested YAML is not supported;
+// lines without a colon are ignored. Returns nil when there is no header.
+func parseFrontMatter(text string) map[string]string {
+	lines := strings.Split(strings.TrimPrefix(text, "\ufeff"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil
+	}
+
+	fields := make(map[string]string)
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "---" || trimmed == "..." {
+			return fields
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key != "" {
+			fields[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	// No closing delimiter: a horizontal rule, not front matter
+	return nil
+}
+
 // findLandmarks finds all landmark declarations in the text.
 func (p *Parser) findLandmarks(text string) []landmarkMatch {
 	var matches []landmarkMatch
@@ -179,20 +225,48 @@ func (p *Parser) findLandmarks(text string) []landmarkMatch {
 	return matches
 }
 
+// findDirectives finds all "simplex-lint:" directive lines in the text.
+func (p *Parser) findDirectives(text string) []Directive {
+	directives := []Directive{}
+	for i, line := range strings.Split(text, "\n") {
+		if m := p.directivePattern.FindStringSubmatch(line); m != nil {
+			directives = append(directives, Directive{Text: m[1], LineNumber: i + 1})
+		}
+	}
+	return directives
+}
+
+// stripDirectives removes directive lines from landmark content.
+func (p *Parser) stripDirectives(content string) string {
+	if !strings.Contains(content, "simplex-lint:") {
+		return content
+	}
+	var kept []string
+	for _, line := range strings.Split(content, "\n") {
+		if !p.directivePattern.MatchString(line) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
 // extractLandmarkContent extracts full content for each landmark.
 func (p *Parser) extractLandmarkContent(text string, matches []landmarkMatch) []Landmark {
 	var landmarks []Landmark
+	totalLines := strings.Count(text, "\n") + 1
 
 	for i, m := range matches {
 		// Content starts after the landmark line
 		contentStart := m.endIndex
 
 		// Content ends at next landmark or EOF
-		var contentEnd int
+		var contentEnd, endLine int
 		if i+1 < len(matches) {
 			contentEnd = matches[i+1].startIndex
+			endLine = matches[i+1].lineNumber - 1
 		} else {
 			contentEnd = len(text)
+			endLine = totalLines
 		}
 
 		// Extract and clean content
@@ -210,8 +284,9 @@ func (p *Parser) extractLandmarkContent(text string, matches []landmarkMatch) []
 
 		landmarks = append(landmarks, Landmark{
 			Name:       m.name,
-			Content:    content,
+			Content:    p.stripDirectives(content),
 			LineNumber: m.lineNumber,
+			EndLine:    endLine,
 		})
 	}
 
@@ -242,6 +317,7 @@ func (p *Parser) organizeLandmarks(spec *ParsedSpec, landmarks []Landmark) {
 			// This is a function-level landmark
 			if currentFunction != nil {
 				currentFunction.Landmarks[lm.Name] = lm
+				currentFunction.EndLine = lm.EndLine
 			} else {
 				// Function landmark without parent FUNCTION - add warning
 				spec.ParseWarnings = append(spec.ParseWarnings,
@@ -249,7 +325,11 @@ func (p *Parser) organizeLandmarks(spec *ParsedSpec, landmarks []Landmark) {
 			}
 
 		default:
-			// Unrecognized landmark - add warning but don't fail
+			// Unrecognized landmark - add warning but don't fail.
+			// It stays inside the current function's line range.
+			if currentFunction != nil {
+				currentFunction.EndLine = lm.EndLine
+			}
 			spec.ParseWarnings = append(spec.ParseWarnings,
 				"unrecognized landmark: "+lm.Name+" at line "+strconv.Itoa(lm.LineNumber))
 		}
@@ -261,6 +341,7 @@ func (p *Parser) parseFunctionBlock(lm Landmark) FunctionBlock {
 	fb := FunctionBlock{
 		Signature:  lm.Content,
 		LineNumber: lm.LineNumber,
+		EndLine:    lm.EndLine,
 		Landmarks:  make(map[string]Landmark),
 	}
 
@@ -307,6 +388,28 @@ func (spec *ParsedSpec) GetFunctionByName(name string) *FunctionBlock {
 	return nil
 }
 
+// LandmarkAtLine returns the name of the nested landmark containing line,
+// or "" if the line is in the FUNCTION header or outside the function.
+func (fb *FunctionBlock) LandmarkAtLine(line int) string {
+	for name, lm := range fb.Landmarks {
+		if line >= lm.LineNumber && line <= lm.EndLine {
+			return name
+		}
+	}
+	return ""
+}
+
+// FunctionAtLine returns the function whose block contains line, or nil.
+func (spec *ParsedSpec) FunctionAtLine(line int) *FunctionBlock {
+	for i := range spec.Functions {
+		fn := &spec.Functions[i]
+		if line >= fn.LineNumber && line <= fn.EndLine {
+			return fn
+		}
+	}
+	return nil
+}
+
 // HasLandmark checks if a function has a specific landmark.
 func (fb *FunctionBlock) HasLandmark(name string) bool {
 	_, ok := fb.Landmarks[name]
@@ -835,3 +835,112 @@ ERRORS:
 	fn := spec.Functions[0]
 	assert.Equal(t, "inline", fn.Name)
 }
+
+func TestParser_Parse_LineRanges(t *testing.T) {
+	spec := `DATA: Item
+  id: string
+
+FUNCTION: first(x) → Item
+
+RULES:
+  - return the item
+
+EXAMPLES:
+  (1) → item
+
+FUNCTION: second(x) → Item
+
+RULES:
+  - return the item`
+
+	p := NewParser()
+	result := p.Parse(spec)
+
+	require.Len(t, result.Functions, 2)
+	assert.Equal(t, 3, result.DataBlocks[0].EndLine)
+
+	first := result.Functions[0]
+	assert.Equal(t, 4, first.LineNumber)
+	assert.Equal(t, 11, first.EndLine)
+	assert.Equal(t, 6, first.Landmarks["RULES"].LineNumber)
+	assert.Equal(t, 8, first.Landmarks["RULES"].EndLine)
+
+	second := result.Functions[1]
+	assert.Equal(t, 12, second.LineNumber)
+	assert.Equal(t, 15, second.EndLine)
+
+	assert.Equal(t, "first", result.FunctionAtLine(7).Name)
+	assert.Equal(t, "second", result.FunctionAtLine(15).Name)
+	assert.Nil(t, result.FunctionAtLine(1))
+	assert.Equal(t, "EXAMPLES", first.LandmarkAtLine(10))
+	assert.Equal(t, "", first.LandmarkAtLine(5))
+}
+
+func TestParser_Parse_UnrecognizedLandmarkExtendsFunction(t *testing.T) {
+	spec := `FUNCTION: f() → result
+
+RULES:
+  - x
+
+NOTES:
+  - free-form notes`
+
+	p := NewParser()
+	result := p.Parse(spec)
+
+	require.Len(t, result.Functions, 1)
+	assert.Equal(t, 7, result.Functions[0].EndLine)
+}
+
+func TestParser_Parse_Directives(t *testing.T) {
+	spec := `# simplex-lint: ignore W011 -- legacy
+
+FUNCTION: f() → result
+
+RULES:
+  # simplex-lint: ignore W010 -- quoted policy text
+  - do the thing
+
+EXAMPLES:
+  <!-- simplex-lint: ignore E012 -- covered elsewhere -->
+  () → ok`
+
+	p := NewParser()
+	result := p.Parse(spec)
+
+	require.Len(t, result.Directives, 3)
+	assert.Equal(t, Directive{Text: "ignore W011 -- legacy", LineNumber: 1}, result.Directives[0])
+	assert.Equal(t, 6, result.Directives[1].LineNumber)
+	assert.Equal(t, "ignore E012 -- covered elsewhere", result.Directives[2].Text)
+
+	// Directive lines are lint metadata, not spec content
+	fn := result.Functions[0]
+	assert.Equal(t, "- do the thing", fn.GetRules())
+	assert.Equal(t, "() → ok", fn.GetExamples())
+}
+
+func TestParser_Parse_FrontMatter(t *testing.T) {
+	spec := `---
+Status: "release"
+owner: payments
+---
+
+FUNCTION: f() → result
+
+RULES:
+  - do the thing`
+
+	p := NewParser()
+	result := p.Parse(spec)
+
+	assert.Equal(t, map[string]string{"status": "release", "owner": "payments"}, result.FrontMatter)
+	require.Len(t, result.Functions, 1)
+	assert.Equal(t, "- do the thing", result.Functions[0].GetRules())
+}
+
+func TestParser_Parse_FrontMatterRequiresClosingDelimiter(t *testing.T) {
+	p := NewParser()
+
+	assert.Nil(t, p.Parse("---\nstatus: release\n\nFUNCTION: f() → result").FrontMatter)
+	assert.Nil(t, p.Parse("FUNCTION: f() → result\n---\nstatus: release\n---").FrontMatter)
+}
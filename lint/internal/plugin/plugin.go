@@ -0,0 +1,162 @@
+// Package plugin runs external checkers. A plugin is any executable that
+// reads a Request as JSON on stdin and writes a Response as JSON on stdout:
+//
+//	{"version": 1, "file": "specs/pay.md", "spec": {"functions": [...], ...}}
+//	{"issues": [{"code": "PAY001", "message": "...", "location": "FUNCTION charge", "severity": "error"}]}
+//
+// Returned issues are merged into the lint result before suppression and
+// rule configuration, so plugin codes can be ignored, disabled or re-levelled
+// like built-in ones. A plugin that fails, times out or returns invalid
+// output is reported as E090 rather than aborting the run.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// ProtocolVersion is sent in every request so plugins can reject input they
+// don't understand.
+const ProtocolVersion = 1
+
+// DefaultTimeout bounds a single plugin invocation when none is configured.
+const DefaultTimeout = 10 * time.Second
+
+// maxStderr caps how much plugin stderr is quoted in an E090 message.
+const maxStderr = 200
+
+// Plugin is an external checker executable.
+type Plugin struct {
+	Name    string
+	Command []string      // executable and arguments
+	Dir     string        // working directory, usually the config file's directory
+	Timeout time.Duration // zero means DefaultTimeout
+}
+
+// Request is the JSON document written to a plugin's stdin.
+type Request struct {
+	Version int                `json:"version"`
+	File    string             `json:"file"`
+	Spec    *parser.ParsedSpec `json:"spec"`
+}
+
+// Response is the JSON document a plugin writes to stdout.
+type Response struct {
+	Issues []result.LintError `json:"issues"`
+}
+
+// Run invokes the plugin on a spec and returns the issues it reports. The
+// plugin is killed when its timeout expires or ctx is done.
+func (p Plugin) Run(ctx context.Context, file string, spec *parser.ParsedSpec) ([]result.LintError, error) {
+	if len(p.Command) == 0 {
+		return nil, fmt.Errorf("no command configured")
+	}
+
+	input, err := json.Marshal(Request{Version: ProtocolVersion, File: file, Spec: spec})
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, p.Command[0], p.Command[1:]...)
+	cmd.Dir = p.Dir
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait on grandchildren that keep stdout open after a kill
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("returned invalid JSON: %w", err)
+	}
+
+	for i := range resp.Issues {
+		if err := normalizeIssue(&resp.Issues[i]); err != nil {
+			return nil, fmt.Errorf("returned invalid issue %d: %w", i+1, err)
+		}
+	}
+	return resp.Issues, nil
+}
+
+// normalizeIssue validates a plugin issue and fills in defaults: severity
+// warning and location "spec".
+func normalizeIssue(e *result.LintError) error {
+	e.Code = strings.ToUpper(strings.TrimSpace(e.Code))
+	if e.Code == "" {
+		return fmt.Errorf("missing code")
+	}
+	if strings.TrimSpace(e.Message) == "" {
+		return fmt.Errorf("%s has no message", e.Code)
+	}
+
+	e.Severity = strings.ToLower(strings.TrimSpace(e.Severity))
+	if e.Severity == "" {
+		e.Severity = result.SeverityWarning
+	}
+	if !result.IsSeverity(e.Severity) {
+		return fmt.Errorf("%s has unknown severity %q", e.Code, e.Severity)
+	}
+
+	if e.Location == "" {
+		e.Location = "spec"
+	}
+	e.Fingerprint = ""
+	return nil
+}
+
+// Check runs the plugin on the spec and merges the issues into r.
+// Error E090: Plugin failed or returned invalid output
+func (p Plugin) Check(ctx context.Context, file string, spec *parser.ParsedSpec, r *result.LintResult) {
+	found, err := p.Run(ctx, file, spec)
+	if err != nil {
+		r.AddError("E090", fmt.Sprintf("plugin %s failed: %v", p.Name, err), "plugin "+p.Name)
+		return
+	}
+	r.SetIssues(append(r.Issues(), found...))
+}
+
+// firstLine returns the first non-empty line of s, truncated for display.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(line) > maxStderr {
+			line = line[:maxStderr] + "..."
+		}
+		return line
+	}
+	return ""
+}
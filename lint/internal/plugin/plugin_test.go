@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+const paymentSpec = `FUNCTION: charge(card, amount) → receipt
+
+RULES:
+  - charge the card
+`
+
+// script writes an executable shell script plugin and returns its path.
+func script(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "plugin.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o755))
+	return path
+}
+
+func spec() *parser.ParsedSpec {
+	return parser.NewParser().Parse(paymentSpec)
+}
+
+func TestRun_ReturnsIssues(t *testing.T) {
+	p := Plugin{Name: "payments", Command: []string{script(t, `cat > /dev/null
+echo '{"issues": [{"code": "pay001", "message": "payment function needs NOT_ALLOWED", "location": "FUNCTION charge", "severity": "Error"}, {"code": "PAY002", "message": "advisory"}]}'
+`)}}
+
+	issues, err := p.Run(context.Background(), "pay.md", spec())
+	require.NoError(t, err)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, result.LintError{
+		Code:     "PAY001",
+		Message:  "payment function needs NOT_ALLOWED",
+		Location: "FUNCTION charge",
+		Severity: result.SeverityError,
+	}, issues[0])
+	assert.Equal(t, result.SeverityWarning, issues[1].Severity)
+	assert.Equal(t, "spec", issues[1].Location)
+}
+
+func TestRun_SendsParsedSpec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "request.json")
+	p := Plugin{Name: "echo", Command: []string{script(t, `cat > "$1"
+echo '{"issues": []}'
+`), out}}
+
+	_, err := p.Run(context.Background(), "pay.md", spec())
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var req struct {
+		Version int    `json:"version"`
+		File    string `json:"file"`
+		Spec    struct {
+			Functions []struct {
+				Name      string                     `json:"name"`
+				Inputs    []string                   `json:"inputs"`
+				Landmarks map[string]json.RawMessage `json:"landmarks"`
+			} `json:"functions"`
+		} `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(data, &req))
+
+	assert.Equal(t, ProtocolVersion, req.Version)
+	assert.Equal(t, "pay.md", req.File)
+	require.Len(t, req.Spec.Functions, 1)
+	assert.Equal(t, "charge", req.Spec.Functions[0].Name)
+	assert.Equal(t, []string{"card", "amount"}, req.Spec.Functions[0].Inputs)
+	assert.Contains(t, req.Spec.Functions[0].Landmarks, "RULES")
+}
+
+func TestRun_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"non-zero exit", "echo 'rules file not found' >&2\nexit 3\n", "rules file not found"},
+		{"invalid json", "echo 'not json'\n", "invalid JSON"},
+		{"missing code", `echo '{"issues": [{"message": "x"}]}'` + "\n", "missing code"},
+		{"bad severity", `echo '{"issues": [{"code": "X1", "message": "x", "severity": "fatal"}]}'` + "\n", "unknown severity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Plugin{Name: "broken", Command: []string{script(t, tt.body)}}
+
+			_, err := p.Run(context.Background(), "pay.md", spec())
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	p := Plugin{
+		Name:    "slow",
+		Command: []string{script(t, "sleep 5\n")},
+		Timeout: 100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	_, err := p.Run(context.Background(), "pay.md", spec())
+
+	assert.ErrorContains(t, err, "timed out after 100ms")
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestRun_MissingExecutable(t *testing.T) {
+	p := Plugin{Name: "gone", Command: []string{filepath.Join(t.TempDir(), "missing")}}
+
+	_, err := p.Run(context.Background(), "pay.md", spec())
+	assert.Error(t, err)
+}
+
+func TestRun_ContextCancelled(t *testing.T) {
+	p := Plugin{Name: "slow", Command: []string{script(t, "sleep 5\n")}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := p.Run(ctx, "pay.md", spec())
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCheck_MergesIssuesAndReportsFailures(t *testing.T) {
+	ok := Plugin{Name: "payments", Command: []string{script(t, `echo '{"issues": [{"code": "PAY001", "message": "needs NOT_ALLOWED", "location": "FUNCTION charge", "severity": "error"}]}'
+`)}}
+	broken := Plugin{Name: "broken", Command: []string{script(t, "exit 1\n")}}
+
+	r := result.NewLintResult("pay.md")
+	r.AddWarning("W012", "no inputs", "FUNCTION charge")
+
+	ok.Check(context.Background(), "pay.md", spec(), r)
+	broken.Check(context.Background(), "pay.md", spec(), r)
+
+	assert.False(t, r.Valid)
+	require.Len(t, r.Errors, 2)
+	assert.Equal(t, "PAY001", r.Errors[0].Code)
+	assert.Equal(t, "E090", r.Errors[1].Code)
+	assert.Equal(t, "plugin broken", r.Errors[1].Location)
+	assert.Contains(t, r.Errors[1].Message, "plugin broken failed")
+	assert.Len(t, r.Warnings, 1)
+}
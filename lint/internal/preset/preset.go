@@ -0,0 +1,118 @@
+// Package preset defines named bundles of rule settings. A preset chooses
+// thresholds, the spec version, rule severities and which rules run; explicit
+// configuration (config file, flags, API options) is layered on top of it.
+package preset
+
+import (
+	"sort"
+	"strings"
+)
+
+// Preset names.
+const (
+	Recommended = "recommended"
+	Strict      = "strict"
+	CompatV03   = "compat-v0.3"
+)
+
+// Preset is a named bundle of rule settings. Zero values mean "use the
+// default", as in lint.Config.
+type Preset struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	SpecVersion   string `json:"spec_version,omitempty"`
+	MaxRules      int    `json:"max_rules,omitempty"`
+	MaxInputs     int    `json:"max_inputs,omitempty"`
+	MaxRuleLength int    `json:"max_rule_length,omitempty"`
+	MaxFunctions  int    `json:"max_functions,omitempty"`
+
+	Disable  []string          `json:"disable,omitempty"`
+	Severity map[string]string `json:"severity,omitempty"`
+
+	// PromoteWarnings reports warnings as errors, except codes given an
+	// explicit severity.
+	PromoteWarnings bool `json:"promote_warnings,omitempty"`
+
+	// EnableOptIn turns on every opt-in rule.
+	EnableOptIn bool `json:"enable_opt_in,omitempty"`
+}
+
+var presets = map[string]Preset{
+	Recommended: {
+		Name:        Recommended,
+		Description: "Default rules and thresholds for specs being written",
+	},
+	Strict: {
+		Name:            Strict,
+		Description:     "Release specs: tighter thresholds, opt-in rules on, warnings reported as errors",
+		MaxRules:        10,
+		MaxInputs:       4,
+		MaxRuleLength:   150,
+		MaxFunctions:    6,
+		PromoteWarnings: true,
+		EnableOptIn:     true,
+	},
+	CompatV03: {
+		Name:          CompatV03,
+		Description:   "Imported v0.3 specs: v0.3 landmarks only, relaxed thresholds, coverage as a warning",
+		SpecVersion:   "0.3",
+		MaxRules:      20,
+		MaxRuleLength: 300,
+		// Checks of the v0.4 and v0.5 landmarks stay off even under a
+		// later spec_version
+		Disable: []string{
+			"E050", "E051", "E052", "E053", "E054", "W050", "W051",
+			"E060", "E061", "E062", "E063", "E064", "E065", "E066", "W066", "W067", "W068",
+			"E070", "E071", "E072", "W070", "W071", "W072", "W073",
+			"E080", "E081", "E082", "W080",
+			"E100", "E101", "E102", "E103", "W100",
+		},
+		Severity: map[string]string{
+			"E012": "warning",
+			"W010": "info",
+			"W011": "info",
+		},
+	},
+}
+
+// defaultStatusPresets maps spec front-matter status values to presets.
+var defaultStatusPresets = map[string]string{
+	"draft":   Recommended,
+	"release": Strict,
+	"legacy":  CompatV03,
+}
+
+// Get returns the preset with the given name, ignoring case.
+func Get(name string) (Preset, bool) {
+	p, ok := presets[strings.ToLower(strings.TrimSpace(name))]
+	return p, ok
+}
+
+// Names returns the preset names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// All returns every preset, sorted by name.
+func All() []Preset {
+	var out []Preset
+	for _, name := range Names() {
+		out = append(out, presets[name])
+	}
+	return out
+}
+
+// StatusPresets returns the default front-matter status to preset mapping.
+func StatusPresets() map[string]string {
+	out := make(map[string]string, len(defaultStatusPresets))
+	for status, name := range defaultStatusPresets {
+		out[status] = name
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package preset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/result"
+	"github.com/thinkwright/simplex/lint/internal/rules"
+)
+
+func TestGet(t *testing.T) {
+	p, ok := Get(" Strict ")
+	require.True(t, ok)
+	assert.Equal(t, Strict, p.Name)
+	assert.True(t, p.PromoteWarnings)
+
+	_, ok = Get("lenient")
+	assert.False(t, ok)
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{CompatV03, Recommended, Strict}, Names())
+}
+
+func TestPresets_ReferenceKnownRules(t *testing.T) {
+	for _, p := range All() {
+		for _, code := range p.Disable {
+			_, ok := rules.Lookup(code)
+			assert.True(t, ok, "%s disables unknown rule %s", p.Name, code)
+		}
+		for code, s := range p.Severity {
+			_, ok := rules.Lookup(code)
+			assert.True(t, ok, "%s sets severity of unknown rule %s", p.Name, code)
+			assert.True(t, result.IsSeverity(s), "%s: %s", p.Name, code)
+		}
+	}
+}
+
+func TestStatusPresets_NameKnownPresets(t *testing.T) {
+	for status, name := range StatusPresets() {
+		_, ok := Get(name)
+		assert.True(t, ok, "status %s maps to unknown preset %s", status, name)
+	}
+}
+
+// TestCompatV03_DisablesLaterLandmarkChecks fails when a check of a v0.4 or
+// v0.5 landmark is added to the catalog but not to compat-v0.3's Disable.
+func TestCompatV03_DisablesLaterLandmarkChecks(t *testing.T) {
+	later := map[string]bool{"Evolution": true, "Determinism": true, "Schema": true, "Data flow": true}
+	p, ok := Get(CompatV03)
+	require.True(t, ok)
+
+	var want []string
+	for _, r := range rules.Builtin() {
+		if later[r.Category] {
+			want = append(want, r.Code)
+		}
+	}
+	require.NotEmpty(t, want)
+	assert.ElementsMatch(t, want, p.Disable)
+}
@@ -2,43 +2,134 @@
 package result
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/fatih/color"
 )
 
-// Severity levels for lint issues
+// Severity levels for lint issues, most severe first
 const (
 	SeverityError   = "error"
 	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityHint    = "hint"
 )
 
+// severityRank orders severities; a lower rank is more severe.
+var severityRank = map[string]int{
+	SeverityError:   0,
+	SeverityWarning: 1,
+	SeverityInfo:    2,
+	SeverityHint:    3,
+}
+
+// IsSeverity reports whether s is a known severity level.
+func IsSeverity(s string) bool {
+	_, ok := severityRank[s]
+	return ok
+}
+
+// AtLeastAsSevere reports whether severity s is as severe as or more severe
+// than min (e.g., "error" is at least as severe as "warning").
+func AtLeastAsSevere(s, min string) bool {
+	rank, ok := severityRank[s]
+	return ok && rank <= severityRank[min]
+}
+
 // LintError represents a single linting issue.
 type LintError struct {
-	Code       string  `json:"code"`                 // e.g., "E001"
-	Message    string  `json:"message"`              // human-readable description
-	Location   string  `json:"location"`             // e.g., "FUNCTION filter_policies" or "line 42"
-	Severity   string  `json:"severity"`             // "error" or "warning"
-	Suggestion *string `json:"suggestion,omitempty"` // optional fix suggestion
-	Fixable    bool    `json:"fixable"`              // can --fix resolve this?
+	Code        string  `json:"code"`                  // e.g., "E001"
+	Message     string  `json:"message"`               // human-readable description
+	Location    string  `json:"location"`              // e.g., "FUNCTION filter_policies" or "line 42"
+	Severity    string  `json:"severity"`              // "error", "warning", "info" or "hint"
+	Suggestion  *string `json:"suggestion,omitempty"`  // optional fix suggestion
+	Fixable     bool    `json:"fixable"`               // can --fix resolve this?
+	Fingerprint string  `json:"fingerprint,omitempty"` // stable identity, see Fingerprint
+
+	// Related are the other functions of the same file the issue involves,
+	// such as the rest of a trigger cycle.
+	Related []string `json:"related,omitempty"`
+}
+
+// numberPattern matches the counts and sizes embedded in issue messages.
+var numberPattern = regexp.MustCompile(`\d+`)
+
+// Fingerprint returns a stable identifier for an issue built from its code,
+// function, landmark and normalized message. Line numbers, counts and
+// whitespace are ignored so that an issue keeps its fingerprint when the spec
+// is edited around it.
+func Fingerprint(e LintError) string {
+	function, landmark := ParseLocation(e.Location)
+	if function == "" && !strings.HasPrefix(e.Location, "line ") {
+		landmark = e.Location // "spec", "parse"
+	}
+
+	h := sha256.New()
+	for _, part := range []string{e.Code, function, landmark, normalizeMessage(e.Message)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// normalizeMessage lower-cases a message, replaces numbers with "#" and
+// collapses whitespace.
+func normalizeMessage(message string) string {
+	message = numberPattern.ReplaceAllString(strings.ToLower(message), "#")
+	return strings.Join(strings.Fields(message), " ")
 }
 
 // LintStats provides summary statistics for a linted spec.
 type LintStats struct {
-	Functions       int     `json:"functions"`
-	Branches        int     `json:"branches"`
-	Examples        int     `json:"examples"`
-	CoveragePercent float64 `json:"coverage_percent,omitempty"`
+	Functions       int           `json:"functions"`
+	Branches        int           `json:"branches"`
+	Examples        int           `json:"examples"`
+	CoveragePercent float64       `json:"coverage_percent,omitempty"`
+	Suppressed      int           `json:"suppressed,omitempty"` // issues silenced by inline directives
+	Baselined       int           `json:"baselined,omitempty"`  // known issues hidden by a snapshot
+	Unchanged       int           `json:"unchanged,omitempty"`  // issues in functions outside --changed-since
+	Timings         []CheckTiming `json:"timings,omitempty"`    // per-check durations, when requested
+
+	// Evolution counts BASELINE items exercised by an example, when the
+	// spec has BASELINE items.
+	Evolution *EvolutionCoverage `json:"evolution,omitempty"`
+
+	// RecursiveTypes names the DATA types that contain themselves, directly
+	// or through other types. Recursion is allowed; this makes it visible.
+	RecursiveTypes []string `json:"recursive_types,omitempty"`
+}
+
+// EvolutionCoverage breaks down how many BASELINE preserve and evolve items
+// an example exercises.
+type EvolutionCoverage struct {
+	Preserve        int `json:"preserve"`
+	PreserveCovered int `json:"preserve_covered"`
+	Evolve          int `json:"evolve"`
+	EvolveCovered   int `json:"evolve_covered"`
+}
+
+// CheckTiming records how long one check took on a spec.
+type CheckTiming struct {
+	Check      string  `json:"check"`
+	DurationMS float64 `json:"duration_ms"`
+	Completed  bool    `json:"completed"` // false if the check was cancelled or timed out
 }
 
 // LintResult represents the complete linting output for a single file.
+// Only errors affect validity; info and hint issues are advisory.
 type LintResult struct {
 	File     string      `json:"file"`
 	Valid    bool        `json:"valid"`
 	Errors   []LintError `json:"errors"`
 	Warnings []LintError `json:"warnings"`
+	Infos    []LintError `json:"infos,omitempty"`
+	Hints    []LintError `json:"hints,omitempty"`
+	Preset   string      `json:"preset,omitempty"` // rule preset the spec was linted with
 	Stats    LintStats   `json:"stats"`
 }
 
@@ -107,6 +198,131 @@ func (r *LintResult) AddWarningWithSuggestion(code, message, location, suggestio
 	})
 }
 
+// AddInfo adds an informational issue to the result (does not affect validity).
+func (r *LintResult) AddInfo(code, message, location string) {
+	r.Infos = append(r.Infos, LintError{
+		Code:     code,
+		Message:  message,
+		Location: location,
+		Severity: SeverityInfo,
+		Fixable:  false,
+	})
+}
+
+// AddHint adds a hint to the result (does not affect validity).
+func (r *LintResult) AddHint(code, message, location string) {
+	r.Hints = append(r.Hints, LintError{
+		Code:     code,
+		Message:  message,
+		Location: location,
+		Severity: SeverityHint,
+		Fixable:  false,
+	})
+}
+
+// ParseLocation splits an issue location of the form "FUNCTION name" or
+// "FUNCTION name LANDMARK" into its function and landmark parts. Locations
+// outside a function (e.g., "spec", "line 42") return empty strings.
+func ParseLocation(location string) (function, landmark string) {
+	rest, ok := strings.CutPrefix(location, "FUNCTION ")
+	if !ok {
+		return "", ""
+	}
+
+	if idx := strings.LastIndex(rest, " "); idx >= 0 && isLandmarkName(rest[idx+1:]) {
+		return rest[:idx], rest[idx+1:]
+	}
+	return rest, ""
+}
+
+// isLandmarkName reports whether s looks like a landmark name (ALL_CAPS).
+func isLandmarkName(s string) bool {
+	if len(s) < 2 || s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	for _, ch := range s {
+		if (ch < 'A' || ch > 'Z') && ch != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Issues returns all issues, most severe first.
+func (r *LintResult) Issues() []LintError {
+	issues := make([]LintError, 0, len(r.Errors)+len(r.Warnings)+len(r.Infos)+len(r.Hints))
+	issues = append(issues, r.Errors...)
+	issues = append(issues, r.Warnings...)
+	issues = append(issues, r.Infos...)
+	issues = append(issues, r.Hints...)
+	return issues
+}
+
+// SetIssues replaces the result's issues, sorting each into Errors, Warnings,
+// Infos or Hints by its Severity and recomputing validity. Issues with an
+// unknown severity are treated as warnings.
+func (r *LintResult) SetIssues(issues []LintError) {
+	r.Errors = []LintError{}
+	r.Warnings = []LintError{}
+	r.Infos = nil
+	r.Hints = nil
+	for _, e := range issues {
+		switch e.Severity {
+		case SeverityError:
+			r.Errors = append(r.Errors, e)
+		case SeverityInfo:
+			r.Infos = append(r.Infos, e)
+		case SeverityHint:
+			r.Hints = append(r.Hints, e)
+		default:
+			e.Severity = SeverityWarning
+			r.Warnings = append(r.Warnings, e)
+		}
+	}
+	r.Valid = len(r.Errors) == 0
+}
+
+// AssignFingerprints sets the Fingerprint of every issue in the result.
+func (r *LintResult) AssignFingerprints() {
+	for _, issues := range [][]LintError{r.Errors, r.Warnings, r.Infos, r.Hints} {
+		for i := range issues {
+			issues[i].Fingerprint = Fingerprint(issues[i])
+		}
+	}
+}
+
+// ApplyRuleOverrides drops issues whose code is disabled and changes the
+// severity of issues whose code has an override.
+func (r *LintResult) ApplyRuleOverrides(disabled map[string]bool, severity map[string]string) {
+	if len(disabled) == 0 && len(severity) == 0 {
+		return
+	}
+
+	var kept []LintError
+	for _, e := range r.Issues() {
+		if disabled[e.Code] {
+			continue
+		}
+		if s, ok := severity[e.Code]; ok {
+			e.Severity = s
+		}
+		kept = append(kept, e)
+	}
+	r.SetIssues(kept)
+}
+
+// PromoteWarnings reports warnings as errors, except for codes listed in
+// keep, whose severity was set explicitly.
+func (r *LintResult) PromoteWarnings(keep map[string]string) {
+	issues := r.Issues()
+	for i, e := range issues {
+		if _, ok := keep[e.Code]; !ok && e.Severity == SeverityWarning {
+			issues[i].Severity = SeverityError
+		}
+	}
+	r.SetIssues(issues)
+}
+
 // ToJSON returns the result as formatted JSON.
 func (r *LintResult) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(r, "", "  ")
@@ -141,10 +357,48 @@ func (r *LintResult) ToText() string {
 		sb.WriteString("\n")
 	}
 
+	// Info
+	if len(r.Infos) > 0 {
+		infoColor := color.New(color.FgCyan, color.Bold)
+		infoColor.Fprintln(&sb, "INFO:")
+		for _, i := range r.Infos {
+			sb.WriteString(formatIssue(i, color.FgCyan))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Hints
+	if len(r.Hints) > 0 {
+		hintColor := color.New(color.Faint, color.Bold)
+		hintColor.Fprintln(&sb, "HINTS:")
+		for _, h := range r.Hints {
+			sb.WriteString(formatIssue(h, color.Faint))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Summary
 	summaryColor := color.New(color.Bold)
 	summaryColor.Fprintln(&sb, "SUMMARY:")
-	sb.WriteString(fmt.Sprintf("  %d error(s), %d warning(s)\n", len(r.Errors), len(r.Warnings)))
+	sb.WriteString(fmt.Sprintf("  %d error(s), %d warning(s)", len(r.Errors), len(r.Warnings)))
+	if len(r.Infos) > 0 || len(r.Hints) > 0 {
+		sb.WriteString(fmt.Sprintf(", %d info, %d hint(s)", len(r.Infos), len(r.Hints)))
+	}
+	sb.WriteString("\n")
+	if r.Preset != "" {
+		sb.WriteString(fmt.Sprintf("  preset: %s\n", r.Preset))
+	}
+
+	if e := r.Stats.Evolution; e != nil {
+		sb.WriteString(fmt.Sprintf("  evolution coverage: preserve %d/%d, evolve %d/%d\n",
+			e.PreserveCovered, e.Preserve, e.EvolveCovered, e.Evolve))
+	}
+	if len(r.Stats.RecursiveTypes) > 0 {
+		sb.WriteString(fmt.Sprintf("  recursive types: %s\n", strings.Join(r.Stats.RecursiveTypes, ", ")))
+	}
+	if len(r.Stats.Timings) > 0 {
+		sb.WriteString(formatTimings(r.Stats.Timings))
+	}
 
 	if r.Valid {
 		validColor := color.New(color.FgGreen, color.Bold)
@@ -161,7 +415,7 @@ func (r *LintResult) ToText() string {
 	return sb.String()
 }
 
-// formatIssue formats a single error or warning for text output.
+// formatIssue formats a single issue for text output.
 func formatIssue(e LintError, c color.Attribute) string {
 	var sb strings.Builder
 	codeColor := color.New(c)
@@ -177,6 +431,20 @@ func formatIssue(e LintError, c color.Attribute) string {
 	return sb.String()
 }
 
+// formatTimings formats per-check durations for text output.
+func formatTimings(timings []CheckTiming) string {
+	var sb strings.Builder
+	sb.WriteString("  timings:\n")
+	for _, t := range timings {
+		status := ""
+		if !t.Completed {
+			status = " (did not complete)"
+		}
+		sb.WriteString(fmt.Sprintf("    %-24s %8.2fms%s\n", t.Check, t.DurationMS, status))
+	}
+	return sb.String()
+}
+
 // NewMultiResult creates a new MultiResult from individual results.
 func NewMultiResult(results []LintResult) *MultiResult {
 	valid := 0
@@ -221,6 +489,40 @@ func (m *MultiResult) ToText() string {
 	return sb.String()
 }
 
+// FailPolicy decides which findings make a lint run fail, independently of
+// spec validity (which only considers errors).
+type FailPolicy struct {
+	FailOn      string // least severe level that fails: error (default), warning or info
+	MaxWarnings int    // fail when a run has more warnings in total than this; negative disables
+}
+
+// DefaultFailPolicy fails on errors only, with no warning budget.
+func DefaultFailPolicy() FailPolicy {
+	return FailPolicy{FailOn: SeverityError, MaxWarnings: -1}
+}
+
+// Fails reports whether r has an issue at or above FailOn. The warning
+// budget applies to a whole run; see ExceedsWarnings.
+func (p FailPolicy) Fails(r *LintResult) bool {
+	failOn := p.FailOn
+	if failOn == "" {
+		failOn = SeverityError
+	}
+
+	for _, e := range r.Issues() {
+		if AtLeastAsSevere(e.Severity, failOn) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExceedsWarnings reports whether a run with the given number of warnings
+// is over the MaxWarnings budget.
+func (p FailPolicy) ExceedsWarnings(warnings int) bool {
+	return p.MaxWarnings >= 0 && warnings > p.MaxWarnings
+}
+
 // AllValid returns true if all results are valid.
 func (m *MultiResult) AllValid() bool {
 	return m.TotalValid == m.TotalFiles
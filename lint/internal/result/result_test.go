@@ -278,3 +278,262 @@ func TestLintResult_ToJSON_WithStats(t *testing.T) {
 	assert.Contains(t, jsonStr, `"examples": 8`)
 	assert.Contains(t, jsonStr, `"coverage_percent": 80`)
 }
+
+func TestLintResult_SetIssues(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddWarning("W010", "long rule", "FUNCTION a")
+
+	r.SetIssues([]LintError{
+		{Code: "E010", Severity: SeverityError},
+		{Code: "W011", Severity: SeverityWarning},
+	})
+
+	assert.False(t, r.Valid)
+	require.Len(t, r.Errors, 1)
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W011", r.Warnings[0].Code)
+	assert.Len(t, r.Issues(), 2)
+}
+
+func TestLintResult_ApplyRuleOverrides(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddError("E012", "too few examples", "FUNCTION a")
+	r.AddWarning("W011", "many functions", "spec")
+	r.AddWarning("W010", "long rule", "FUNCTION a")
+
+	r.ApplyRuleOverrides(
+		map[string]bool{"W011": true},
+		map[string]string{"E012": SeverityWarning, "W010": SeverityError},
+	)
+
+	assert.False(t, r.Valid)
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "W010", r.Errors[0].Code)
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "E012", r.Warnings[0].Code)
+	assert.Equal(t, SeverityWarning, r.Warnings[0].Severity)
+}
+
+func TestLintResult_ApplyRuleOverrides_None(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddError("E012", "too few examples", "FUNCTION a")
+
+	r.ApplyRuleOverrides(nil, nil)
+
+	assert.False(t, r.Valid)
+	assert.Len(t, r.Errors, 1)
+}
+
+func TestLintResult_PromoteWarnings(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddWarning("W010", "long rule", "FUNCTION a")
+	r.AddWarning("W011", "many functions", "spec")
+	r.AddInfo("W006", "undefined type", "FUNCTION a")
+
+	r.PromoteWarnings(map[string]string{"W011": SeverityWarning})
+
+	assert.False(t, r.Valid)
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "W010", r.Errors[0].Code)
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W011", r.Warnings[0].Code)
+	assert.Len(t, r.Infos, 1)
+}
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		location string
+		function string
+		landmark string
+	}{
+		{"FUNCTION filter_policies", "filter_policies", ""},
+		{"FUNCTION migrate EVAL", "migrate", "EVAL"},
+		{"FUNCTION compute DETERMINISM", "compute", "DETERMINISM"},
+		{"FUNCTION (unnamed)", "(unnamed)", ""},
+		{"FUNCTION not a signature", "not a signature", ""},
+		{"spec", "", ""},
+		{"line 42", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			function, landmark := ParseLocation(tt.location)
+			assert.Equal(t, tt.function, function)
+			assert.Equal(t, tt.landmark, landmark)
+		})
+	}
+}
+
+func TestLintResult_AddInfoAndHint(t *testing.T) {
+	r := NewLintResult("test.md")
+
+	r.AddInfo("W011", "many functions", "spec")
+	r.AddHint("W010", "long rule", "FUNCTION a")
+
+	assert.True(t, r.Valid)
+	require.Len(t, r.Infos, 1)
+	assert.Equal(t, SeverityInfo, r.Infos[0].Severity)
+	require.Len(t, r.Hints, 1)
+	assert.Equal(t, SeverityHint, r.Hints[0].Severity)
+	assert.Len(t, r.Issues(), 2)
+}
+
+func TestLintResult_SetIssues_AllSeverities(t *testing.T) {
+	r := NewLintResult("test.md")
+
+	r.SetIssues([]LintError{
+		{Code: "E001", Severity: SeverityError},
+		{Code: "W001", Severity: SeverityWarning},
+		{Code: "W010", Severity: SeverityInfo},
+		{Code: "W011", Severity: SeverityHint},
+		{Code: "X001", Severity: "bogus"},
+	})
+
+	assert.False(t, r.Valid)
+	assert.Len(t, r.Errors, 1)
+	require.Len(t, r.Warnings, 2)
+	assert.Equal(t, SeverityWarning, r.Warnings[1].Severity)
+	assert.Len(t, r.Infos, 1)
+	assert.Len(t, r.Hints, 1)
+}
+
+func TestLintResult_ApplyRuleOverrides_ToInfo(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddError("E012", "too few examples", "FUNCTION a")
+
+	r.ApplyRuleOverrides(nil, map[string]string{"E012": SeverityInfo})
+
+	assert.True(t, r.Valid)
+	assert.Empty(t, r.Errors)
+	require.Len(t, r.Infos, 1)
+	assert.Equal(t, "E012", r.Infos[0].Code)
+}
+
+func TestLintResult_ToText_InfoAndHints(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddInfo("W011", "many functions", "spec")
+	r.AddHint("W010", "long rule", "FUNCTION a")
+
+	text := r.ToText()
+
+	assert.Contains(t, text, "INFO:")
+	assert.Contains(t, text, "HINTS:")
+	assert.Contains(t, text, "0 error(s), 0 warning(s), 1 info, 1 hint(s)")
+	assert.Contains(t, text, "VALID")
+}
+
+func TestLintResult_ToJSON_OmitsEmptyInfoAndHints(t *testing.T) {
+	r := NewLintResult("test.md")
+
+	data, err := r.ToJSON()
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "infos")
+	assert.NotContains(t, string(data), "hints")
+}
+
+func TestAtLeastAsSevere(t *testing.T) {
+	assert.True(t, AtLeastAsSevere(SeverityError, SeverityWarning))
+	assert.True(t, AtLeastAsSevere(SeverityWarning, SeverityWarning))
+	assert.False(t, AtLeastAsSevere(SeverityInfo, SeverityWarning))
+	assert.False(t, AtLeastAsSevere("bogus", SeverityHint))
+	assert.True(t, IsSeverity(SeverityHint))
+	assert.False(t, IsSeverity("fatal"))
+}
+
+func TestFailPolicy_Fails(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddWarning("W010", "long rule", "FUNCTION a")
+	r.AddWarning("W011", "many functions", "spec")
+	r.AddInfo("W012", "no inputs", "FUNCTION a")
+	r.AddHint("W013", "style", "FUNCTION a")
+
+	tests := []struct {
+		name   string
+		policy FailPolicy
+		want   bool
+	}{
+		{"default passes warnings", DefaultFailPolicy(), false},
+		{"empty fail-on means error", FailPolicy{MaxWarnings: -1}, false},
+		{"fail on warning", FailPolicy{FailOn: SeverityWarning, MaxWarnings: -1}, true},
+		{"fail on info", FailPolicy{FailOn: SeverityInfo, MaxWarnings: -1}, true},
+		{"warning budget is per run", FailPolicy{FailOn: SeverityError, MaxWarnings: 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Fails(r))
+		})
+	}
+}
+
+func TestFailPolicy_ExceedsWarnings(t *testing.T) {
+	assert.False(t, DefaultFailPolicy().ExceedsWarnings(100))
+	assert.False(t, FailPolicy{MaxWarnings: 2}.ExceedsWarnings(2))
+	assert.True(t, FailPolicy{MaxWarnings: 2}.ExceedsWarnings(3))
+	assert.True(t, FailPolicy{MaxWarnings: 0}.ExceedsWarnings(1))
+}
+
+func TestFailPolicy_FailsOnErrors(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddError("E001", "No FUNCTION block found", "spec")
+
+	assert.True(t, DefaultFailPolicy().Fails(r))
+}
+
+func TestFingerprint_IgnoresCountsAndWhitespace(t *testing.T) {
+	a := LintError{Code: "E011", Message: "FUNCTION has 8 inputs (max 6)", Location: "FUNCTION f"}
+	b := LintError{Code: "E011", Message: "FUNCTION  has 9 inputs (max 6)", Location: "FUNCTION f"}
+
+	assert.Equal(t, Fingerprint(a), Fingerprint(b))
+	assert.Len(t, Fingerprint(a), 16)
+}
+
+func TestFingerprint_DistinguishesCodeFunctionAndLandmark(t *testing.T) {
+	base := LintError{Code: "W010", Message: "RULES item too long", Location: "FUNCTION f RULES"}
+
+	otherCode := base
+	otherCode.Code = "W011"
+	otherFunction := base
+	otherFunction.Location = "FUNCTION g RULES"
+	otherLandmark := base
+	otherLandmark.Location = "FUNCTION f"
+
+	assert.NotEqual(t, Fingerprint(base), Fingerprint(otherCode))
+	assert.NotEqual(t, Fingerprint(base), Fingerprint(otherFunction))
+	assert.NotEqual(t, Fingerprint(base), Fingerprint(otherLandmark))
+}
+
+func TestFingerprint_IgnoresLineNumbers(t *testing.T) {
+	a := LintError{Code: "W003", Message: "directive does not suppress anything", Location: "line 4"}
+	b := LintError{Code: "W003", Message: "directive does not suppress anything", Location: "line 12"}
+
+	assert.Equal(t, Fingerprint(a), Fingerprint(b))
+}
+
+func TestLintResult_AssignFingerprints(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.AddError("E001", "No FUNCTION block found", "spec")
+	r.AddHint("W010", "long rule", "FUNCTION f RULES")
+
+	r.AssignFingerprints()
+
+	assert.Equal(t, Fingerprint(r.Errors[0]), r.Errors[0].Fingerprint)
+	assert.NotEmpty(t, r.Hints[0].Fingerprint)
+}
+
+func TestLintResult_ToText_Timings(t *testing.T) {
+	r := NewLintResult("test.md")
+	r.Stats.Timings = []CheckTiming{
+		{Check: "structural", DurationMS: 0.25, Completed: true},
+		{Check: "plugin payments", DurationMS: 10000, Completed: false},
+	}
+
+	text := r.ToText()
+
+	assert.Contains(t, text, "timings:")
+	assert.Contains(t, text, "structural")
+	assert.Contains(t, text, "0.25ms")
+	assert.Contains(t, text, "plugin payments")
+	assert.Contains(t, text, "(did not complete)")
+}
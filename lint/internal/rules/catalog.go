@@ -0,0 +1,1390 @@
+package rules
+
+import "github.com/thinkwright/simplex/lint/internal/result"
+
+// catalog documents every rule the built-in checkers report, sorted by code.
+// The docs appendix in docs/lint-design.md is generated from it.
+var catalog = []Rule{
+	{
+		Code:      "E001",
+		Category:  "Structural",
+		Title:     "No FUNCTION block found",
+		Severity:  result.SeverityError,
+		Rationale: "A spec describes behavior through FUNCTION blocks. Without one there is nothing for an agent to implement or for the linter to check.",
+		Bad: `DATA: Invoice
+  id: string
+  total: number`,
+		Good: `FUNCTION: total(invoice) → number
+
+RULES:
+  - return the sum of the invoice line amounts
+
+DONE_WHEN:
+  - result equals the sum of line amounts
+
+EXAMPLES:
+  (invoice with lines 2 and 3) → 5
+
+ERRORS:
+  - any unhandled condition → fail with descriptive message`,
+	},
+	{
+		Code:      "E002",
+		Category:  "Structural",
+		Title:     "FUNCTION missing RULES",
+		Severity:  result.SeverityError,
+		Rationale: "RULES state what the function must do. Without them the examples are the only description of behavior, and an agent has to guess the general case.",
+		Bad: `FUNCTION: greet(name) → string
+
+DONE_WHEN:
+  - greeting returned`,
+		Good: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name
+
+DONE_WHEN:
+  - greeting returned`,
+	},
+	{
+		Code:      "E003",
+		Category:  "Structural",
+		Title:     "FUNCTION missing DONE_WHEN",
+		Severity:  result.SeverityError,
+		Rationale: "DONE_WHEN gives observable completion criteria. Without it an agent cannot tell when the work is finished or verify that it is correct.",
+		Bad: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name`,
+		Good: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name
+
+DONE_WHEN:
+  - greeting contains name`,
+	},
+	{
+		Code:      "E004",
+		Category:  "Structural",
+		Title:     "FUNCTION missing EXAMPLES",
+		Severity:  result.SeverityError,
+		Rationale: "EXAMPLES pin down the RULES with concrete input and output pairs. They remove ambiguity that prose alone leaves open and double as test cases.",
+		Bad: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name`,
+		Good: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name
+
+EXAMPLES:
+  ("Ada") → "Hello, Ada"`,
+	},
+	{
+		Code:      "E005",
+		Category:  "Structural",
+		Title:     "FUNCTION missing ERRORS",
+		Severity:  result.SeverityError,
+		Fixable:   true,
+		Rationale: "ERRORS say what happens when things go wrong. Without them failure behavior is left to the implementer, and unexpected input is silently mishandled.",
+		Bad: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name`,
+		Good: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name
+
+ERRORS:
+  - any unhandled condition → fail with descriptive message`,
+	},
+	{
+		Code:      "E006",
+		Category:  "Structural",
+		Title:     "DATA type defined more than once",
+		Severity:  result.SeverityError,
+		Rationale: "Two DATA blocks with the same name leave readers and agents guessing which fields apply; checks use the last one. Merge the blocks or rename one.",
+		Bad: `DATA: User
+  id: string
+
+DATA: User
+  name: string
+
+FUNCTION: get_user(id) → User`,
+		Good: `DATA: User
+  id: string
+  name: string
+
+FUNCTION: get_user(id) → User`,
+	},
+	{
+		Code:      "E007",
+		Category:  "Structural",
+		Title:     "Example argument count doesn't match FUNCTION inputs",
+		Severity:  result.SeverityError,
+		Rationale: "An example that passes more or fewer arguments than the FUNCTION takes can't be run as a test, and is a frequent slip in generated specs. Positional arguments fill inputs in order; inputs written limit? or limit = 10 are optional. Examples whose arguments are prose phrases, and variadic inputs, are not counted.",
+		Bad: `FUNCTION: add_to_cart(cart, item) → cart
+
+EXAMPLES:
+  (empty_cart) → [apple]`,
+		Good: `FUNCTION: add_to_cart(cart, item) → cart
+
+EXAMPLES:
+  (empty_cart, apple) → [apple]`,
+	},
+	{
+		Code:      "E008",
+		Category:  "Structural",
+		Title:     "Example names an argument that isn't a FUNCTION input",
+		Severity:  result.SeverityError,
+		Rationale: "Named arguments such as (cart: empty, item: apple) must use the input names from the signature, each at most once. A misspelled or stale name leaves the real input without a value.",
+		Bad: `FUNCTION: add_to_cart(cart, item) → cart
+
+EXAMPLES:
+  (cart: empty, itme: apple) → [apple]`,
+		Good: `FUNCTION: add_to_cart(cart, item) → cart
+
+EXAMPLES:
+  (cart: empty, item: apple) → [apple]`,
+	},
+	{
+		Code:      "E010",
+		Category:  "Complexity",
+		Title:     "RULES block exceeds max items",
+		Severity:  result.SeverityError,
+		Rationale: "A long RULES block usually means the function does too much. Splitting it keeps each function small enough to specify, implement and verify on its own. The limit defaults to 15 and is set by max_rules.",
+		Bad: `FUNCTION: process(order) → receipt
+
+RULES:
+  - validate the customer
+  - validate the address
+  - validate each line item
+  - check stock for each item
+  - reserve stock
+  - apply discounts
+  - apply loyalty points
+  - compute tax
+  - compute shipping
+  - charge the card
+  - record the payment
+  - send the confirmation email
+  - notify the warehouse
+  - update analytics
+  - schedule the follow-up email
+  - return the receipt`,
+		Good: `FUNCTION: price(order) → quote
+
+RULES:
+  - apply discounts and loyalty points
+  - compute tax and shipping
+  - return the quote
+
+FUNCTION: checkout(order, quote) → receipt
+
+RULES:
+  - charge the card for the quoted total
+  - return the receipt`,
+	},
+	{
+		Code:      "E011",
+		Category:  "Complexity",
+		Title:     "FUNCTION has too many inputs",
+		Severity:  result.SeverityError,
+		Rationale: "Many inputs multiply the cases the RULES and EXAMPLES must cover. Group related inputs into a DATA type or split the function. The limit defaults to 6 and is set by max_inputs.",
+		Bad:       `FUNCTION: ship(name, street, city, zip, country, weight, speed) → label`,
+		Good: `DATA: Address
+  name: string
+  street: string
+  city: string
+  zip: string
+  country: string
+
+FUNCTION: ship(address, weight, speed) → label`,
+	},
+	{
+		Code:      "E012",
+		Category:  "Complexity",
+		Title:     "EXAMPLES fewer than branch count",
+		Severity:  result.SeverityError,
+		Rationale: "Fallback for E020 when RULES branches can't be told apart by their terms (e.g. a condition that only names an input), or an uncovered branch's terms appear in no example: each conditional branch still needs at least one example, so the example count must reach the branch count.",
+		Bad: `FUNCTION: pick(a, b) → result
+
+RULES:
+  - if a, return a
+  - if b, return b
+  - otherwise return nothing
+
+EXAMPLES:
+  (1, _) → 1`,
+		Good: `FUNCTION: pick(a, b) → result
+
+RULES:
+  - if a, return a
+  - if b, return b
+  - otherwise return nothing
+
+EXAMPLES:
+  (1, _) → 1
+  (_, 2) → 2
+  (_, _) → nothing`,
+	},
+	{
+		Code:      "E013",
+		Category:  "Complexity",
+		Title:     "Examples with the same input have different outputs",
+		Severity:  result.SeverityError,
+		Rationale: "Two examples that give one input different outputs allow conflicting implementations, which makes the spec ambiguous. Inputs and outputs are compared after normalizing whitespace, quotes, number formatting, object field order and named arguments. Fields listed under DETERMINISM vary may differ, and two examples whose comments start with given and describe different state the input doesn't show (# given a card on file) are not compared.",
+		Bad: `FUNCTION: add_to_cart(cart, item) → cart
+
+EXAMPLES:
+  (empty, "apple") → ["apple"]
+  (empty, "apple") → ["pear"]`,
+		Good: `FUNCTION: add_to_cart(cart, item) → cart
+
+EXAMPLES:
+  (empty, "apple") → ["apple"]
+  (empty, "pear") → ["pear"]`,
+	},
+	{
+		Code:      "E020",
+		Category:  "Coverage",
+		Title:     "RULES branch not covered by examples",
+		Severity:  result.SeverityError,
+		Rationale: "Each conditional branch in RULES (if/or, if/otherwise, either/or, optionally) needs an example that exercises it. An example covers a branch when the branch's condition terms appear in its input or comment, or, for an otherwise branch, it meets none of the sibling conditions. Outputs don't count, since sibling branches often share an outcome. Five examples of the same branch don't cover the others.",
+		Bad: `FUNCTION: sign(n) → string
+
+RULES:
+  - if n is negative, return "negative"
+  - if n is zero, return "zero"
+  - if n is positive, return "positive"
+
+EXAMPLES:
+  (-4) → "negative"
+  (-9) → "negative"
+  (-1) → "negative"`,
+		Good: `FUNCTION: sign(n) → string
+
+RULES:
+  - if n is negative, return "negative"
+  - if n is zero, return "zero"
+  - if n is positive, return "positive"
+
+EXAMPLES:
+  (-4) → "negative"
+  (0) → "zero"
+  (7) → "positive"`,
+	},
+	{
+		Code:      "E030",
+		Category:  "Observability",
+		Title:     "DONE_WHEN item is not observable",
+		Severity:  result.SeverityError,
+		Rationale: "An agent decides it is finished by checking DONE_WHEN from the outside. Criteria about internal state, variables or data structures can't be checked that way. The not_observable term list decides which phrases count; outputs, return values, side effects and SharedMemory are observable.",
+		Bad: `FUNCTION: dedupe(items) → unique
+
+DONE_WHEN:
+  - internal counter reaches zero`,
+		Good: `FUNCTION: dedupe(items) → unique
+
+DONE_WHEN:
+  - output contains no duplicates`,
+	},
+	{
+		Code:      "E040",
+		Category:  "Behavioral",
+		Title:     "RULES item is procedural",
+		Severity:  result.SeverityError,
+		Rationale: "RULES describe outcomes, not the steps to reach them: step-by-step rules fix one implementation and hide what must be true of the output. Reported per item, with the matched phrase, when every item in the block is procedural. The procedural term list (loop, iterate, for each, step 1, then, initialize, increment, ...) is set under terms: procedural.",
+		Bad: `FUNCTION: collect(items) → matches
+
+RULES:
+  - loop through items and add matches
+  - then sort the matches`,
+		Good: `FUNCTION: collect(items) → matches
+
+RULES:
+  - items matching criteria are included
+  - matches are sorted by name`,
+	},
+	{
+		Code:      "E041",
+		Category:  "Behavioral",
+		Title:     "Procedural item in a behavioral RULES block",
+		Severity:  result.SeverityError,
+		Rationale: "A block that mostly states outcomes but slips in a step mixes two kinds of instruction, and an agent can't tell whether the step is required. Reported for each procedural item when the block also has behavioral ones; restate the step as the outcome it produces.",
+		Bad: `FUNCTION: collect(items) → matches
+
+RULES:
+  - items matching criteria are included
+  - increment the match count`,
+		Good: `FUNCTION: collect(items) → matches
+
+RULES:
+  - items matching criteria are included
+  - the match count equals the number of matches`,
+	},
+	{
+		Code:      "E050",
+		Category:  "Evolution",
+		Title:     "BASELINE requires reference field",
+		Severity:  result.SeverityError,
+		Rationale: "BASELINE describes a change to existing behavior. The reference names what is being changed so preserve and evolve items have something to be measured against.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes`,
+	},
+	{
+		Code:      "E051",
+		Category:  "Evolution",
+		Title:     "BASELINE requires preserve field",
+		Severity:  result.SeverityError,
+		Rationale: "preserve lists the behavior that must not regress. Without it every change is fair game and the evolution cannot be evaluated safely.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  evolve:
+    - add indexes`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes`,
+	},
+	{
+		Code:      "E052",
+		Category:  "Evolution",
+		Title:     "BASELINE requires evolve field",
+		Severity:  result.SeverityError,
+		Rationale: "evolve lists the behavior that is meant to change. Without it there is no stated goal for the new version.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes`,
+	},
+	{
+		Code:      "E053",
+		Category:  "Evolution",
+		Title:     "BASELINE preserve must contain at least one item",
+		Severity:  result.SeverityError,
+		Rationale: "An empty preserve list states nothing about what must keep working. List at least one behavior that must not regress.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+  evolve:
+    - add indexes`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes`,
+	},
+	{
+		Code:      "E054",
+		Category:  "Evolution",
+		Title:     "BASELINE evolve must contain at least one item",
+		Severity:  result.SeverityError,
+		Rationale: "An empty evolve list states no intended change. List at least one behavior the new version should add or alter.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes`,
+	},
+	{
+		Code:      "E060",
+		Category:  "Evolution",
+		Title:     "EVAL required when BASELINE present",
+		Severity:  result.SeverityError,
+		Fixable:   true,
+		Rationale: "A BASELINE only matters if the change can be evaluated. EVAL sets how reliably preserved behavior must hold and how often evolved behavior must succeed.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "E061",
+		Category:  "Evolution",
+		Title:     "EVAL requires preserve threshold when BASELINE present",
+		Severity:  result.SeverityError,
+		Rationale: "The preserve threshold sets how reliably the preserved behavior must hold. Without it regressions have no pass bar.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  evolve: pass@5
+  grading: code`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "E062",
+		Category:  "Evolution",
+		Title:     "EVAL requires evolve threshold when BASELINE present",
+		Severity:  result.SeverityError,
+		Rationale: "The evolve threshold sets how often the new behavior must succeed. Without it the change has no pass bar.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  grading: code`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "E063",
+		Category:  "Evolution",
+		Title:     "preserve threshold must use pass^k notation",
+		Severity:  result.SeverityError,
+		Rationale: "Preserved behavior must hold every time, so it is measured with pass^k: all k trials pass. pass@k would accept a regression that shows up in only some runs.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass@3
+  evolve: pass@5
+  grading: code`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "E064",
+		Category:  "Evolution",
+		Title:     "evolve threshold must use pass@k notation",
+		Severity:  result.SeverityError,
+		Rationale: "New behavior is measured with pass@k: at least one of k trials passes. Requiring every trial to pass, or using another notation, does not match how the spec defines evolution.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: 80%
+  grading: code`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "E065",
+		Category:  "Evolution",
+		Title:     "grading must be code, model, or outcome",
+		Severity:  result.SeverityError,
+		Rationale: "Grading says who decides whether a trial passed: code assertions, a model judge, or an observed outcome. Other values cannot be run by an evaluator.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: fuzzy`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "E066",
+		Category:  "Evolution",
+		Title:     "threshold k must be positive integer",
+		Severity:  result.SeverityError,
+		Rationale: "k is the number of trials. pass^0 and pass@0 run no trials, so every change passes and the threshold measures nothing.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^0
+  evolve: pass@5
+  grading: code`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "E070",
+		Category:  "Determinism",
+		Title:     "DETERMINISM level must be strict, structural, or semantic",
+		Severity:  result.SeverityError,
+		Rationale: "The level tells an evaluator how to compare repeated outputs: byte for byte, by shape, or by meaning. A missing or unknown level leaves that comparison undefined.",
+		Bad: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: exact`,
+		Good: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict
+  seed: from input hash`,
+	},
+	{
+		Code:      "E071",
+		Category:  "Determinism",
+		Title:     "DETERMINISM seed must be from input hash, from timestamp, or none",
+		Severity:  result.SeverityError,
+		Rationale: "The seed tells an evaluator where randomness comes from so runs can be reproduced. Only these three sources are defined; a literal number or other text can't be applied across implementations.",
+		Bad: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict
+  seed: 42`,
+		Good: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict
+  seed: from input hash`,
+	},
+	{
+		Code:      "E072",
+		Category:  "Determinism",
+		Title:     "Field listed in both vary and stable",
+		Severity:  result.SeverityError,
+		Rationale: "vary lists what may differ between runs and stable what must not. An entry in both makes the comparison impossible to define.",
+		Bad: `FUNCTION: search(query) → results
+
+DETERMINISM:
+  level: structural
+  vary: ordering, total_count
+  stable: total_count`,
+		Good: `FUNCTION: search(query) → results
+
+DETERMINISM:
+  level: structural
+  vary: ordering
+  stable: total_count`,
+	},
+	{
+		Code:      "E080",
+		Category:  "Schema",
+		Title:     "Return type references undefined DATA block",
+		Severity:  result.SeverityError,
+		Rationale: "From v0.5 a capitalized return type is an output schema: example outputs are checked against its DATA block. A name with no DATA block leaves the output shape undefined, so the examples can't be checked and agents have to guess the fields.",
+		Bad: `DATA: Receipt
+  id: string
+
+FUNCTION: charge(card, amount) → Reciept`,
+		Good: `DATA: Receipt
+  id: string
+
+FUNCTION: charge(card, amount) → Receipt`,
+	},
+	{
+		Code:      "E081",
+		Category:  "Schema",
+		Title:     "Example output missing required field",
+		Severity:  result.SeverityError,
+		Rationale: "An example output is a promise about the shape of real outputs. Leaving out a field the DATA block requires contradicts the schema; mark the field optional or \"present when ...\" if it is sometimes absent.",
+		Bad: `DATA: Receipt
+  id: string
+  total: number
+
+FUNCTION: charge(card, amount) → Receipt
+
+EXAMPLES:
+  (visa, 10) → { id: "r1" }`,
+		Good: `DATA: Receipt
+  id: string
+  total: number
+
+FUNCTION: charge(card, amount) → Receipt
+
+EXAMPLES:
+  (visa, 10) → { id: "r1", total: 10 }`,
+	},
+	{
+		Code:      "E082",
+		Category:  "Schema",
+		Title:     "Example output field has wrong type",
+		Severity:  result.SeverityError,
+		Rationale: "A field whose example value doesn't match its declared type (a string for a boolean, a value outside an enum) leaves an agent two contradicting answers. Bare words such as total_price are placeholders and match any type except an enum.",
+		Bad: `DATA: Receipt
+  id: string
+  paid: boolean
+
+FUNCTION: charge(card, amount) → Receipt
+
+EXAMPLES:
+  (visa, 10) → { id: "r1", paid: "yes" }`,
+		Good: `DATA: Receipt
+  id: string
+  paid: boolean
+
+FUNCTION: charge(card, amount) → Receipt
+
+EXAMPLES:
+  (visa, 10) → { id: "r1", paid: true }`,
+	},
+	{
+		Code:      "E090",
+		Category:  "Plugin",
+		Title:     "Checker plugin failed or returned invalid output",
+		Severity:  result.SeverityError,
+		Rationale: "A plugin that crashes, times out or prints invalid JSON has not checked the spec. Reporting it as an error keeps a broken plugin from looking like a clean result. The message quotes the plugin's first line of stderr.",
+	},
+	{
+		Code:      "E091",
+		Category:  "Runtime",
+		Title:     "Check did not complete (cancelled, timed out or panicked)",
+		Severity:  result.SeverityError,
+		Rationale: "When --timeout expires, the run is interrupted or a check panics, the remaining checks never report. E091 names each of them so an incomplete result is never mistaken for a clean one.",
+	},
+	{
+		Code:      "E100",
+		Category:  "Data flow",
+		Title:     "Dangling read",
+		Severity:  result.SeverityError,
+		Rationale: "A READS key that no function WRITES has no producer: usually a renamed key or a typo. Keys are dotted paths (artifacts.registry_path); free-form entries are skipped. Keys under a data_flow external prefix are produced outside the specs and exempt. Runs when two or more functions declare keys.",
+		Bad: `FUNCTION: compile(registry) → artifacts
+
+WRITES:
+  - status.compilation: success | failure
+
+FUNCTION: deploy(artifacts) → report
+
+READS:
+  - status.compile`,
+		Good: `FUNCTION: compile(registry) → artifacts
+
+WRITES:
+  - status.compilation: success | failure
+
+FUNCTION: deploy(artifacts) → report
+
+READS:
+  - status.compilation`,
+	},
+	{
+		Code:      "E101",
+		Category:  "Data flow",
+		Title:     "Dangling trigger",
+		Severity:  result.SeverityError,
+		Rationale: "A TRIGGERS entry watches a key that no function WRITES, so the trigger can never fire and the function is never picked up. Triggers take the form key exists, key == value or key != value.",
+		Bad: `FUNCTION: compile(registry) → artifacts
+
+WRITES:
+  - status.compilation: success | failure
+
+FUNCTION: deploy(artifacts) → report
+
+TRIGGERS:
+  - status.compiled == success`,
+		Good: `FUNCTION: compile(registry) → artifacts
+
+WRITES:
+  - status.compilation: success | failure
+
+FUNCTION: deploy(artifacts) → report
+
+TRIGGERS:
+  - status.compilation == success`,
+	},
+	{
+		Code:      "E102",
+		Category:  "Data flow",
+		Title:     "Trigger cycle",
+		Severity:  result.SeverityError,
+		Rationale: "Functions whose WRITES fire each other's TRIGGERS can activate one another forever, the most common failure in long-running agent workflows. Trigger conditions are not evaluated, so any write to a watched key counts. Each cycle is reported once, as an ordered path, at the TRIGGERS of its earliest function; mark an intentional loop with an ignore directive there.",
+		Bad: `FUNCTION: draft(topic) → text
+
+WRITES:
+  - status.drafted
+
+TRIGGERS:
+  - status.reviewed == rejected
+
+FUNCTION: review(text) → verdict
+
+WRITES:
+  - status.reviewed: approved | rejected
+
+TRIGGERS:
+  - status.drafted exists`,
+		Good: `FUNCTION: draft(topic) → text
+
+WRITES:
+  - status.drafted
+
+TRIGGERS:
+  - status.reviewed == rejected
+  # simplex-lint: ignore E102 -- redraft until approved, capped by review rounds
+
+FUNCTION: review(text) → verdict
+
+WRITES:
+  - status.reviewed: approved | rejected
+
+TRIGGERS:
+  - status.drafted exists`,
+	},
+	{
+		Code:      "E103",
+		Category:  "Data flow",
+		Title:     "Self-activation",
+		Severity:  result.SeverityError,
+		Rationale: "A function that triggers on a key it writes fires again after every run. Write a different key for the next step, or mark a deliberate polling loop with an ignore directive in its TRIGGERS.",
+		Bad: `FUNCTION: poll(queue) → job
+
+WRITES:
+  - queue.next
+
+TRIGGERS:
+  - queue.next exists`,
+		Good: `FUNCTION: poll(queue) → job
+
+WRITES:
+  - queue.claimed
+
+TRIGGERS:
+  - queue.next exists`,
+	},
+	{
+		Code:      "W001",
+		Category:  "Structural",
+		Title:     "Unrecognized or misplaced landmark",
+		Severity:  result.SeverityWarning,
+		Rationale: "Landmarks are matched by exact name. A typo or a landmark outside a FUNCTION is ignored by agents and checks alike, so its content silently goes missing.",
+		Bad: `FUNCTION: greet(name) → string
+
+RULE:
+  - return "Hello, " followed by name`,
+		Good: `FUNCTION: greet(name) → string
+
+RULES:
+  - return "Hello, " followed by name`,
+	},
+	{
+		Code:      "W002",
+		Category:  "Directive",
+		Title:     "Malformed suppression directive",
+		Severity:  result.SeverityWarning,
+		Rationale: "A directive that cannot be parsed suppresses nothing. Flagging it keeps an author from believing an issue is silenced when it is not.",
+		Bad:       `# simplex-lint: ignore`,
+		Good:      `# simplex-lint: ignore W011 -- generated spec, reviewed`,
+	},
+	{
+		Code:      "W003",
+		Category:  "Directive",
+		Title:     "Suppression directive does not suppress anything",
+		Severity:  result.SeverityWarning,
+		Rationale: "A directive that matches no issue is stale, usually because the issue was fixed. Left in place it would hide the issue if it came back.",
+		Bad: `# simplex-lint: ignore E011 -- legacy API
+
+FUNCTION: add(a, b) → number`,
+		Good: `FUNCTION: add(a, b) → number`,
+	},
+	{
+		Code:      "W006",
+		Category:  "Structural",
+		Title:     "DATA type referenced but not defined",
+		Severity:  result.SeverityWarning,
+		Rationale: "Once a spec defines DATA types, a type name that matches none of them is probably a typo or a missing definition. Return types, typed inputs (items: list of Item) and DATA fields are resolved through lists and unions; enum values and primitives such as string need no definition, and builtin_types lists other names that don't. Agents cannot infer the fields of an undefined type. From v0.5, an undefined capitalized return type such as Reciept is E080 instead.",
+		Bad: `DATA: Receipt
+  id: string
+
+FUNCTION: charge(card, amount) → reciept`,
+		Good: `DATA: Receipt
+  id: string
+
+FUNCTION: charge(card, amount) → Receipt`,
+	},
+	{
+		Code:      "W007",
+		Category:  "Structural",
+		Title:     "DATA type never referenced",
+		Severity:  result.SeverityWarning,
+		Rationale: "A DATA block no return type, typed input or other DATA field names is either left over or meant for an input whose type the signature doesn't state. A type that only refers to itself still counts as unreferenced.",
+		Bad: `DATA: Criteria
+  tags: list of string
+
+FUNCTION: filter(policies, criteria) → filtered list`,
+		Good: `DATA: Criteria
+  tags: list of string
+
+FUNCTION: filter(policies, criteria: Criteria) → filtered list`,
+	},
+	{
+		Code:      "W010",
+		Category:  "Complexity",
+		Title:     "Single RULES item too long",
+		Severity:  result.SeverityWarning,
+		Rationale: "A very long rule usually packs several conditions into one sentence, which hides branches from E012 and from readers. The limit defaults to 200 characters and is set by max_rule_length.",
+		Bad: `FUNCTION: ship(order) → label
+
+RULES:
+  - when the order is domestic and under two kilograms use standard post unless the customer paid for express, in which case use the courier, and when the order is international use the courier unless it contains batteries`,
+		Good: `FUNCTION: ship(order) → label
+
+RULES:
+  - if the order is domestic and under 2 kg, use standard post
+  - if the customer paid for express, use the courier
+  - if the order is international, use the courier
+  - orders containing batteries never ship internationally`,
+	},
+	{
+		Code:      "W011",
+		Category:  "Complexity",
+		Title:     "Many FUNCTION blocks in spec",
+		Severity:  result.SeverityWarning,
+		Rationale: "A spec with many functions is hard to review and usually covers more than one concern. Split it into several specs. The limit defaults to 10 and is set by max_functions.",
+	},
+	{
+		Code:      "W013",
+		Category:  "Complexity",
+		Title:     "Example duplicates an earlier example",
+		Severity:  result.SeverityWarning,
+		Rationale: "A repeated example exercises nothing new but still counts toward the E012 example total. Examples are compared after normalizing whitespace, quotes, number formatting, object field order and named arguments, so (1.0) → 'a' repeats (1) → \"a\". Duplicates are left out of the E012 count.",
+		Bad: `FUNCTION: sign(n) → label
+
+EXAMPLES:
+  (1) → "positive"
+  (1.0) → 'positive'`,
+		Good: `FUNCTION: sign(n) → label
+
+EXAMPLES:
+  (1) → "positive"
+  (-1) → "negative"`,
+	},
+	{
+		Code:      "W030",
+		Category:  "Observability",
+		Title:     "DONE_WHEN item needs clarification",
+		Severity:  result.SeverityWarning,
+		OptIn:     true,
+		Rationale: "Criteria like \"all items processed\" are observable only if processing leaves visible evidence. An item with a vague term and no observable one (output, returned, written, ...) should say how completion shows. Both lists are set under terms: vague and observable.",
+		Bad: `FUNCTION: import(rows) → report
+
+DONE_WHEN:
+  - all rows processed`,
+		Good: `FUNCTION: import(rows) → report
+
+DONE_WHEN:
+  - report lists every row as imported or rejected`,
+	},
+	{
+		Code:      "W050",
+		Category:  "Evolution",
+		Title:     "preserve item has no corresponding example",
+		Severity:  result.SeverityWarning,
+		Rationale: "A preserve item without an example has no regression test, so pass^k never measures it. An example covers an item when it shares a term with it; tag the line [preserve] or [preserve N] when the wording differs.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EXAMPLES:
+  (orders_db) → report listing new indexes`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EXAMPLES:
+  (orders_db) → report listing new indexes
+  (legacy_query) → same rows as before`,
+	},
+	{
+		Code:      "W051",
+		Category:  "Evolution",
+		Title:     "evolve item has no corresponding example",
+		Severity:  result.SeverityWarning,
+		Rationale: "An evolve item without an example has no capability test, so pass@k can't show the new behavior works. Matching works as for W050, with [evolve] or [evolve N] tags.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EXAMPLES:
+  (legacy_query) → same rows as before`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EXAMPLES:
+  (legacy_query) → same rows as before
+  (orders_db) → report  # [evolve]`,
+	},
+	{
+		Code:      "W066",
+		Category:  "Evolution",
+		Title:     "threshold k is implausibly large",
+		Severity:  result.SeverityWarning,
+		Rationale: "Each of the k trials is a full run of the function. A k in the hundreds or thousands is usually a typo, and an evaluator can't afford to honor it. The limit defaults to 100 and is set by max_eval_k.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@1000
+  grading: code`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "W067",
+		Category:  "Evolution",
+		Title:     "grading: outcome on a function without side effects",
+		Severity:  result.SeverityWarning,
+		Rationale: "An outcome grader checks the state a run leaves behind. A function that declares no WRITES or HANDOFF has no declared effect to observe, so the grader has nothing to check.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: outcome`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+WRITES:
+  - schema_version
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: outcome`,
+	},
+	{
+		Code:      "W068",
+		Category:  "Evolution",
+		Title:     "grading: code with free-text example outputs",
+		Severity:  result.SeverityWarning,
+		Rationale: "A code grader compares outputs mechanically. Outputs written as prose, such as \"same rows as before\", can't be compared that way; write them as values or grade with a model.",
+		Bad: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EXAMPLES:
+  (legacy_query) → same rows as before
+  (orders_db) → report listing new indexes
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+		Good: `FUNCTION: migrate(db) → report
+
+BASELINE:
+  reference: "schema v2.1"
+  preserve:
+    - existing queries work
+  evolve:
+    - add indexes
+
+EXAMPLES:
+  (legacy_query) → { rows: 42 }
+  (orders_db) → { indexes: ["orders_by_date"] }
+
+EVAL:
+  preserve: pass^3
+  evolve: pass@5
+  grading: code`,
+	},
+	{
+		Code:      "W070",
+		Category:  "Determinism",
+		Title:     "strict determinism should specify seed",
+		Severity:  result.SeverityWarning,
+		Rationale: "Strict determinism promises identical outputs, which only holds if any randomness is seeded the same way each run. Say where the seed comes from, or none if there is no randomness.",
+		Bad: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict`,
+		Good: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict
+  seed: none`,
+	},
+	{
+		Code:      "W071",
+		Category:  "Determinism",
+		Title:     "vary without stable",
+		Severity:  result.SeverityWarning,
+		Rationale: "A vary list says what may change but not what must hold, so an evaluator has to guess which differences are regressions. List the stable fields too.",
+		Bad: `FUNCTION: search(query) → results
+
+DETERMINISM:
+  level: structural
+  vary: ordering of equal scores`,
+		Good: `FUNCTION: search(query) → results
+
+DETERMINISM:
+  level: structural
+  vary: ordering of equal scores
+  stable: all scores, total_count`,
+	},
+	{
+		Code:      "W072",
+		Category:  "Determinism",
+		Title:     "strict determinism conflicts with EVAL",
+		Severity:  result.SeverityWarning,
+		Rationale: "level: strict requires identical outputs, but a pass@k evolve threshold accepts runs that differ and grading: model judges meaning rather than exact output. One of the two settings is probably wrong.",
+		Bad: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict
+  seed: from input hash
+
+EVAL:
+  grading: model`,
+		Good: `FUNCTION: hash(data) → string
+
+DETERMINISM:
+  level: strict
+  seed: from input hash
+
+EVAL:
+  grading: code`,
+	},
+	{
+		Code:      "W073",
+		Category:  "Determinism",
+		Title:     "vary or stable names a field not in the return DATA",
+		Severity:  result.SeverityWarning,
+		Rationale: "An entry written as a field name (total_count, items.score) should name a field of the DATA type the function returns; otherwise it is a typo or a stale name. Entries written as prose are not checked.",
+		Bad: `DATA: Page
+  items: list of string
+  total_count: integer
+
+FUNCTION: search(query) → Page
+
+DETERMINISM:
+  level: structural
+  vary: ordering of items
+  stable: total`,
+		Good: `DATA: Page
+  items: list of string
+  total_count: integer
+
+FUNCTION: search(query) → Page
+
+DETERMINISM:
+  level: structural
+  vary: ordering of items
+  stable: total_count`,
+	},
+	{
+		Code:      "W080",
+		Category:  "Schema",
+		Title:     "Example output contains field not in schema",
+		Severity:  result.SeverityWarning,
+		OptIn:     true,
+		Rationale: "A field the DATA block doesn't declare, or marks not allowed, is either missing from the schema or shouldn't be in the output. Either way the example and the schema disagree.",
+		Bad: `DATA: Receipt
+  id: string
+
+FUNCTION: charge(card, amount) → Receipt
+
+EXAMPLES:
+  (visa, 10) → { id: "r1", card_number: "4111" }`,
+		Good: `DATA: Receipt
+  id: string
+
+FUNCTION: charge(card, amount) → Receipt
+
+EXAMPLES:
+  (visa, 10) → { id: "r1" }`,
+	},
+	{
+		Code:      "W100",
+		Category:  "Data flow",
+		Title:     "Unreachable write",
+		Severity:  result.SeverityWarning,
+		Rationale: "A WRITES key that no function READS or TRIGGERS on is either dead output or a sign of a missing consumer. It is only a warning because systems outside the specs may read it; list their prefixes under data_flow: external.",
+		Bad: `FUNCTION: compile(registry) → artifacts
+
+WRITES:
+  - status.compilation: success | failure
+  - status.compiled_at: timestamp
+
+FUNCTION: deploy(artifacts) → report
+
+TRIGGERS:
+  - status.compilation == success`,
+		Good: `FUNCTION: compile(registry) → artifacts
+
+WRITES:
+  - status.compilation: success | failure
+
+FUNCTION: deploy(artifacts) → report
+
+TRIGGERS:
+  - status.compilation == success`,
+	},
+	{
+		Code:      "W110",
+		Category:  "Errors",
+		Title:     "ERRORS has no catch-all item",
+		Severity:  result.SeverityWarning,
+		Fixable:   true,
+		Rationale: "Specific ERRORS items cover the failures the author foresaw. Without a catch-all, each agent decides on its own what happens on any other failure: one crashes, another returns a default, another retries. An item whose condition says any unhandled condition, unexpected or any other error is the catch-all; the phrases are set under terms: catch_all.",
+		Bad: `FUNCTION: load(path) → config
+
+ERRORS:
+  - file not found → fail with "file not found: {path}"`,
+		Good: `FUNCTION: load(path) → config
+
+ERRORS:
+  - file not found → fail with "file not found: {path}"
+  - any unhandled condition → fail with descriptive message`,
+	},
+	{
+		Code:      "W111",
+		Category:  "Errors",
+		Title:     "ERRORS item not exercised by any example",
+		Severity:  result.SeverityWarning,
+		OptIn:     true,
+		Rationale: "Error paths are where implementations diverge most, and an ERRORS item without an example leaves its trigger and its message to interpretation. An example exercises an item when its output shows one of the item's quoted messages, with {placeholders} matching any text, or when it shows an error and mentions every term of the item's condition. The catch-all needs no example.",
+		Bad: `FUNCTION: load(path) → config
+
+EXAMPLES:
+  ("app.yaml") → { name: "app" }
+
+ERRORS:
+  - file not found → fail with "file not found: {path}"
+  - any unhandled condition → fail with descriptive message`,
+		Good: `FUNCTION: load(path) → config
+
+EXAMPLES:
+  ("app.yaml") → { name: "app" }
+  ("gone.yaml") → Error: file not found: gone.yaml
+
+ERRORS:
+  - file not found → fail with "file not found: {path}"
+  - any unhandled condition → fail with descriptive message`,
+	},
+	{
+		Code:      "W112",
+		Category:  "Errors",
+		Title:     "Example shows an error no ERRORS item names",
+		Severity:  result.SeverityWarning,
+		OptIn:     true,
+		Rationale: "An example whose output is an error (Error: ..., fail with ..., or an object with a non-null error field) documents a failure the spec never declares, so nothing says which other inputs produce it. Its output should match an ERRORS item as W111 describes. The catch-all doesn't count: an error specific enough to show in an example is specific enough to list.",
+		Bad: `FUNCTION: load(path) → config
+
+EXAMPLES:
+  ("app.yaml") → { name: "app" }
+  ("locked.yaml") → Error: permission denied
+
+ERRORS:
+  - any unhandled condition → fail with descriptive message`,
+		Good: `FUNCTION: load(path) → config
+
+EXAMPLES:
+  ("app.yaml") → { name: "app" }
+  ("locked.yaml") → Error: permission denied
+
+ERRORS:
+  - unreadable file → fail with "permission denied"
+  - any unhandled condition → fail with descriptive message`,
+	},
+}
@@ -0,0 +1,148 @@
+// Package rules lists the rule codes simplex-lint can report.
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Rule describes a rule code. Rationale and the Bad/Good spec snippets are
+// optional; the built-in catalog fills them in for simplex-lint explain.
+type Rule struct {
+	Code      string `json:"code"`                // e.g., "E010"
+	Category  string `json:"category"`            // e.g., "Complexity"
+	Title     string `json:"title"`               // one-line description
+	Severity  string `json:"severity"`            // default severity
+	Fixable   bool   `json:"fixable"`             // can --fix resolve it?
+	OptIn     bool   `json:"opt_in,omitempty"`    // off unless enabled or turned on by a preset
+	Rationale string `json:"rationale,omitempty"` // why the rule exists
+	Bad       string `json:"bad,omitempty"`       // spec snippet that triggers the rule
+	Good      string `json:"good,omitempty"`      // corrected snippet
+}
+
+// Builtin returns the built-in rules sorted by code.
+func Builtin() []Rule {
+	out := make([]Rule, len(catalog))
+	copy(out, catalog)
+	return out
+}
+
+// OptIn returns the codes of the built-in rules that are off by default.
+func OptIn() []string {
+	var codes []string
+	for _, r := range catalog {
+		if r.OptIn {
+			codes = append(codes, r.Code)
+		}
+	}
+	return codes
+}
+
+// Lookup returns the built-in rule for a code, ignoring case.
+func Lookup(code string) (Rule, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for _, r := range catalog {
+		if r.Code == code {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Merge combines rule lists sorted by code. When a code appears more than
+// once, the first definition wins.
+func Merge(lists ...[]Rule) []Rule {
+	seen := make(map[string]bool)
+	var out []Rule
+	for _, list := range lists {
+		for _, r := range list {
+			if seen[r.Code] {
+				continue
+			}
+			seen[r.Code] = true
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// Table renders rules as the text table of simplex-lint rules.
+func Table(rules []Rule) string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CODE\tSEVERITY\tCATEGORY\tFIXABLE\tTITLE")
+	for _, r := range rules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Code, r.Severity, r.Category, yesNo(r.Fixable), r.Title)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+// Explain renders one rule with its rationale and snippets, as shown by
+// simplex-lint explain.
+func Explain(r Rule) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s\n", r.Code, r.Title)
+	fmt.Fprintf(&b, "Category: %s   Severity: %s   Fixable: %s\n", r.Category, r.Severity, yesNo(r.Fixable))
+	if r.Rationale != "" {
+		fmt.Fprintf(&b, "\n%s\n", r.Rationale)
+	}
+	if r.Bad != "" {
+		fmt.Fprintf(&b, "\nBad:\n%s\n", indent(r.Bad))
+	}
+	if r.Good != "" {
+		fmt.Fprintf(&b, "\nGood:\n%s\n", indent(r.Good))
+	}
+	return b.String()
+}
+
+// indent prefixes every non-empty line of s with four spaces.
+func indent(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "    " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Markdown renders rules as the docs appendix: a summary table followed by
+// one section per rule with its rationale and spec snippets.
+func Markdown(rules []Rule) string {
+	var b strings.Builder
+	b.WriteString("| Code | Category | Severity | Fixable | Title |\n")
+	b.WriteString("|------|----------|----------|---------|-------|\n")
+	for _, r := range rules {
+		fmt.Fprintf(&b, "| [%s](#%s) | %s | %s | %s | %s |\n",
+			r.Code, strings.ToLower(r.Code), r.Category, r.Severity, yesNo(r.Fixable), r.Title)
+	}
+
+	for _, r := range rules {
+		kind := r.Severity
+		if r.OptIn {
+			kind += ", opt-in"
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n**%s** (%s, %s)\n", r.Code, r.Title, r.Category, kind)
+		if r.Rationale != "" {
+			fmt.Fprintf(&b, "\n%s\n", r.Rationale)
+		}
+		if r.Bad != "" {
+			fmt.Fprintf(&b, "\nBad:\n\n```\n%s\n```\n", r.Bad)
+		}
+		if r.Good != "" {
+			fmt.Fprintf(&b, "\nGood:\n\n```\n%s\n```\n", r.Good)
+		}
+	}
+	return b.String()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
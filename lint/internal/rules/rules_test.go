@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func TestBuiltin_SortedAndUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	var codes []string
+	for _, r := range Builtin() {
+		assert.False(t, seen[r.Code], "duplicate %s", r.Code)
+		seen[r.Code] = true
+		codes = append(codes, r.Code)
+		assert.True(t, result.IsSeverity(r.Severity), r.Code)
+		assert.NotEmpty(t, r.Title, r.Code)
+		assert.NotEmpty(t, r.Rationale, r.Code)
+	}
+	assert.IsIncreasing(t, codes)
+}
+
+func TestMerge_FirstDefinitionWins(t *testing.T) {
+	merged := Merge(
+		[]Rule{{Code: "E001", Title: "builtin"}},
+		[]Rule{{Code: "X001", Title: "custom"}, {Code: "E001", Title: "override"}},
+	)
+
+	assert.Equal(t, []Rule{{Code: "E001", Title: "builtin"}, {Code: "X001", Title: "custom"}}, merged)
+}
+
+func TestLookup(t *testing.T) {
+	r, ok := Lookup(" e012 ")
+	require.True(t, ok)
+	assert.Equal(t, "E012", r.Code)
+	assert.NotEmpty(t, r.Bad)
+
+	_, ok = Lookup("E999")
+	assert.False(t, ok)
+}
+
+func TestExplain(t *testing.T) {
+	out := Explain(Rule{Code: "E999", Title: "Example", Category: "Structural", Severity: result.SeverityError, Fixable: true, Bad: "FUNCTION: f()\n\nRULES:"})
+	assert.Contains(t, out, "E999: Example\n")
+	assert.Contains(t, out, "Fixable: yes")
+	assert.Contains(t, out, "Bad:\n    FUNCTION: f()\n\n    RULES:")
+	assert.NotContains(t, out, "Good:")
+}
+
+var update = flag.Bool("update", false, "rewrite the generated docs appendix")
+
+const (
+	docsPath    = "../../../docs/lint-design.md"
+	beginMarker = "<!-- BEGIN GENERATED RULES: go test ./internal/rules -update -->\n"
+	endMarker   = "<!-- END GENERATED RULES -->"
+)
+
+// TestDocsAppendix keeps the rule reference in docs/lint-design.md in sync
+// with the catalog. Run with -update after changing the catalog.
+func TestDocsAppendix(t *testing.T) {
+	data, err := os.ReadFile(docsPath)
+	require.NoError(t, err)
+	doc := string(data)
+
+	start := strings.Index(doc, beginMarker)
+	end := strings.Index(doc, endMarker)
+	require.True(t, start >= 0 && end > start, "docs appendix markers not found")
+	start += len(beginMarker)
+
+	want := Markdown(Builtin())
+	if *update {
+		doc = doc[:start] + want + doc[end:]
+		require.NoError(t, os.WriteFile(docsPath, []byte(doc), 0o644))
+		return
+	}
+	assert.Equal(t, want, doc[start:end], "docs appendix is stale; run go test ./internal/rules -update")
+}
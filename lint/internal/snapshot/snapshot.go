@@ -0,0 +1,185 @@
+// Package snapshot records the issues a set of specs already has so that a
+// lint run can fail only on new ones. Issues are matched by fingerprint (see
+// result.Fingerprint), so unrelated edits to a spec don't invalidate its
+// entries. Entries for issues that have since been fixed are pruned.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// FormatVersion is the snapshot file format version.
+const FormatVersion = 1
+
+// Entry is a recorded issue. Code, location and message are kept alongside
+// the fingerprint so that snapshot diffs are reviewable.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	Code        string `json:"code"`
+	Location    string `json:"location"`
+	Message     string `json:"message"`
+}
+
+// Snapshot maps spec files to their recorded issues. A fingerprint may
+// appear more than once when a spec has several identical issues.
+type Snapshot struct {
+	Version int                `json:"version"`
+	Files   map[string][]Entry `json:"files"`
+}
+
+// New creates an empty snapshot.
+func New() *Snapshot {
+	return &Snapshot{Version: FormatVersion, Files: make(map[string][]Entry)}
+}
+
+// Load reads the snapshot at path.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+
+	s := New()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if s.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s", s.Version, path)
+	}
+	if s.Files == nil {
+		s.Files = make(map[string][]Entry)
+	}
+	return s, nil
+}
+
+// LoadOrNew reads the snapshot at path, or returns an empty one if the file
+// does not exist.
+func LoadOrNew(path string) (*Snapshot, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return New(), nil
+	}
+	return Load(path)
+}
+
+// Write saves the snapshot to path.
+func (s *Snapshot) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot %s: %w", path, err)
+	}
+	return nil
+}
+
+// Record replaces the entries for r's file with r's current issues, which
+// prunes the entries of fixed issues. An incomplete result, where a check
+// did not run to the end (E090, E091), only adds entries: the issues of the
+// failed check are missing from it, not fixed. E090 and E091 themselves are
+// never recorded.
+func (s *Snapshot) Record(r *result.LintResult) {
+	file := fileKey(r.File)
+
+	var entries []Entry
+	for _, e := range r.Issues() {
+		if incompleteCodes[e.Code] {
+			continue
+		}
+		entries = append(entries, Entry{
+			Fingerprint: fingerprint(e),
+			Code:        e.Code,
+			Location:    e.Location,
+			Message:     e.Message,
+		})
+	}
+	if incomplete(r) {
+		entries = merge(s.Files[file], entries)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Fingerprint < entries[j].Fingerprint
+	})
+
+	if len(entries) == 0 {
+		delete(s.Files, file)
+		return
+	}
+	s.Files[file] = entries
+}
+
+// incompleteCodes are the codes of checks that did not run to the end.
+var incompleteCodes = map[string]bool{"E090": true, "E091": true}
+
+// incomplete reports whether a check failed or was abandoned while linting
+// r, so that some of its issues may be missing.
+func incomplete(r *result.LintResult) bool {
+	for _, e := range r.Issues() {
+		if incompleteCodes[e.Code] {
+			return true
+		}
+	}
+	return false
+}
+
+// merge returns the entries of old plus those of current that old doesn't
+// already hold as many times.
+func merge(old, current []Entry) []Entry {
+	have := make(map[string]int)
+	for _, e := range old {
+		have[e.Fingerprint]++
+	}
+	out := append([]Entry(nil), old...)
+	for _, e := range current {
+		if have[e.Fingerprint] > 0 {
+			have[e.Fingerprint]--
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// Filter removes issues recorded in the snapshot from r and counts them in
+// r.Stats.Baselined. It never changes the snapshot, so a read-only run can't
+// lose entries; Record prunes fixed issues.
+func (s *Snapshot) Filter(r *result.LintResult) {
+	remaining := make(map[string]int)
+	for _, e := range s.Files[fileKey(r.File)] {
+		remaining[e.Fingerprint]++
+	}
+	if len(remaining) == 0 {
+		return
+	}
+
+	var kept []result.LintError
+	for _, e := range r.Issues() {
+		fp := fingerprint(e)
+		if remaining[fp] > 0 {
+			remaining[fp]--
+			r.Stats.Baselined++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	r.SetIssues(kept)
+}
+
+// fingerprint returns the issue's fingerprint, computing it if unset.
+func fingerprint(e result.LintError) string {
+	if e.Fingerprint != "" {
+		return e.Fingerprint
+	}
+	return result.Fingerprint(e)
+}
+
+// fileKey normalizes a file name so that snapshots are portable across
+// platforms.
+func fileKey(name string) string {
+	return filepath.ToSlash(filepath.Clean(name))
+}
@@ -0,0 +1,140 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+func legacyResult() *result.LintResult {
+	r := result.NewLintResult("specs/legacy.md")
+	r.AddError("E011", "FUNCTION has 8 inputs (max 6)", "FUNCTION wide")
+	r.AddWarning("W010", "RULES item 2 is too long (240 chars)", "FUNCTION wide RULES")
+	r.AddWarning("W010", "RULES item 5 is too long (260 chars)", "FUNCTION wide RULES")
+	return r
+}
+
+func TestRecordAndFilter_HidesKnownIssues(t *testing.T) {
+	s := New()
+	s.Record(legacyResult())
+	require.Len(t, s.Files["specs/legacy.md"], 3)
+
+	r := legacyResult()
+	s.Filter(r)
+
+	assert.True(t, r.Valid)
+	assert.Empty(t, r.Issues())
+	assert.Equal(t, 3, r.Stats.Baselined)
+}
+
+func TestFilter_ReportsNewIssues(t *testing.T) {
+	s := New()
+	s.Record(legacyResult())
+
+	r := legacyResult()
+	r.AddWarning("W010", "RULES item 7 is too long (300 chars)", "FUNCTION wide RULES")
+	r.AddError("E010", "RULES has 16 items (max 15)", "FUNCTION wide")
+	s.Filter(r)
+
+	assert.False(t, r.Valid)
+	assert.Equal(t, 3, r.Stats.Baselined)
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E010", r.Errors[0].Code)
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W010", r.Warnings[0].Code)
+}
+
+func TestFilter_LeavesSnapshotUnchanged(t *testing.T) {
+	s := New()
+	s.Record(legacyResult())
+
+	r := result.NewLintResult("specs/legacy.md")
+	r.AddWarning("W010", "RULES item 2 is too long (240 chars)", "FUNCTION wide RULES")
+	s.Filter(r)
+
+	assert.Equal(t, 1, r.Stats.Baselined)
+	assert.Len(t, s.Files["specs/legacy.md"], 3, "fixed issues are pruned by Record only")
+}
+
+func TestRecord_PrunesFixedIssues(t *testing.T) {
+	s := New()
+	s.Record(legacyResult())
+
+	r := result.NewLintResult("specs/legacy.md")
+	r.AddWarning("W010", "RULES item 2 is too long (240 chars)", "FUNCTION wide RULES")
+	s.Record(r)
+
+	require.Len(t, s.Files["specs/legacy.md"], 1)
+	assert.Equal(t, "W010", s.Files["specs/legacy.md"][0].Code)
+}
+
+func TestRecord_IncompleteResultKeepsEntries(t *testing.T) {
+	s := New()
+	s.Record(legacyResult())
+
+	r := result.NewLintResult("specs/legacy.md")
+	r.AddWarning("W010", "RULES item 2 is too long (240 chars)", "FUNCTION wide RULES")
+	r.AddWarning("W006", "Return type 'Receipt' may reference undefined DATA type", "FUNCTION wide")
+	r.AddError("E091", "check complexity did not complete: context deadline exceeded", "check complexity")
+	s.Record(r)
+
+	var codes []string
+	for _, e := range s.Files["specs/legacy.md"] {
+		codes = append(codes, e.Code)
+	}
+	assert.ElementsMatch(t, []string{"E011", "W010", "W010", "W006"}, codes, "new issues added, none pruned, E091 not recorded")
+}
+
+func TestFilter_OtherFilesUntouched(t *testing.T) {
+	s := New()
+	s.Record(legacyResult())
+
+	r := result.NewLintResult("specs/other.md")
+	r.AddError("E011", "FUNCTION has 8 inputs (max 6)", "FUNCTION wide")
+
+	s.Filter(r)
+	assert.Len(t, r.Errors, 1)
+	assert.Len(t, s.Files["specs/legacy.md"], 3)
+}
+
+func TestRecord_CleanFileRemovesEntry(t *testing.T) {
+	s := New()
+	s.Record(legacyResult())
+	s.Record(result.NewLintResult("specs/legacy.md"))
+
+	assert.Empty(t, s.Files)
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	s := New()
+	s.Record(legacyResult())
+	require.NoError(t, s.Write(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, s.Files, loaded.Files)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	bad := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte(`{"version": 99, "files": {}}`), 0o644))
+	_, err = Load(bad)
+	assert.ErrorContains(t, err, "unsupported snapshot version")
+}
+
+func TestLoadOrNew_MissingFile(t *testing.T) {
+	s, err := LoadOrNew(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Empty(t, s.Files)
+}
@@ -0,0 +1,179 @@
+// Package suppress applies inline "simplex-lint: ignore" directives to lint
+// results. A directive silences the listed codes for the scope it appears in:
+//
+//	# simplex-lint: ignore E011, W010 -- legacy API, reviewed in #412
+//
+// Placed before the first FUNCTION (or in a DATA/CONSTRAINT block) it applies
+// to the whole spec. Placed in a FUNCTION header it applies to that function.
+// Placed inside a nested landmark such as RULES it applies only to issues
+// reported against that landmark.
+package suppress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// Directive is a parsed ignore directive and the scope it applies to.
+type Directive struct {
+	Codes      []string
+	Reason     string
+	LineNumber int
+	Function   string // function name, "" for spec scope
+	Landmark   string // nested landmark name, "" for spec or function scope
+}
+
+// Scope returns a human-readable description of the directive's scope.
+func (d Directive) Scope() string {
+	switch {
+	case d.Function == "":
+		return "spec"
+	case d.Landmark == "":
+		return "FUNCTION " + d.Function
+	default:
+		return "FUNCTION " + d.Function + " " + d.Landmark
+	}
+}
+
+// covers reports whether the directive's scope contains an issue location.
+func (d Directive) covers(location string) bool {
+	if d.Function == "" {
+		return true
+	}
+	function, landmark := result.ParseLocation(location)
+	if function != d.Function {
+		return false
+	}
+	return d.Landmark == "" || landmark == d.Landmark
+}
+
+// Parse extracts ignore directives from the spec and resolves their scope.
+// Malformed directives are returned as warnings rather than directives.
+// Warning W002: Malformed suppression directive
+func Parse(spec *parser.ParsedSpec) ([]Directive, []result.LintError) {
+	var directives []Directive
+	var problems []result.LintError
+
+	for _, raw := range spec.Directives {
+		loc := fmt.Sprintf("line %d", raw.LineNumber)
+
+		d, err := parseDirective(raw.Text)
+		if err != "" {
+			problems = append(problems, result.LintError{
+				Code:     "W002",
+				Message:  err,
+				Location: loc,
+				Severity: result.SeverityWarning,
+			})
+			continue
+		}
+
+		d.LineNumber = raw.LineNumber
+		if fn := spec.FunctionAtLine(raw.LineNumber); fn != nil {
+			d.Function = functionName(fn.Name)
+			d.Landmark = fn.LandmarkAtLine(raw.LineNumber)
+		}
+		directives = append(directives, d)
+	}
+
+	return directives, problems
+}
+
+// parseDirective parses directive text such as "ignore E011 W010 -- reason".
+// It returns a non-empty message when the text is not a valid directive.
+func parseDirective(text string) (Directive, string) {
+	body, reason, _ := strings.Cut(text, "--")
+	fields := strings.FieldsFunc(body, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+
+	if len(fields) == 0 || strings.ToLower(fields[0]) != "ignore" {
+		return Directive{}, fmt.Sprintf("unknown simplex-lint directive: %q (expected \"ignore CODE -- reason\")", text)
+	}
+
+	var codes []string
+	for _, f := range fields[1:] {
+		codes = append(codes, strings.ToUpper(f))
+	}
+	if len(codes) == 0 {
+		return Directive{}, "simplex-lint ignore directive must name at least one rule code"
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return Directive{}, fmt.Sprintf("simplex-lint ignore directive for %s requires a reason after \"--\"",
+			strings.Join(codes, ", "))
+	}
+
+	return Directive{Codes: codes, Reason: reason}, ""
+}
+
+// Apply removes issues silenced by the spec's directives from r, records the
+// count in r.Stats.Suppressed, and reports directive problems.
+// Warning W002: Malformed suppression directive
+// Warning W003: Suppression directive does not suppress anything
+func Apply(spec *parser.ParsedSpec, r *result.LintResult) {
+	directives, problems := Parse(spec)
+	if len(directives) == 0 && len(problems) == 0 {
+		return
+	}
+
+	used := make([]map[string]bool, len(directives))
+	for i := range used {
+		used[i] = make(map[string]bool)
+	}
+
+	var kept []result.LintError
+	for _, e := range r.Issues() {
+		suppressed := false
+		for i, d := range directives {
+			if containsCode(d.Codes, e.Code) && d.covers(e.Location) {
+				used[i][e.Code] = true
+				suppressed = true
+			}
+		}
+		if suppressed {
+			r.Stats.Suppressed++
+			continue
+		}
+		kept = append(kept, e)
+	}
+
+	kept = append(kept, problems...)
+	for i, d := range directives {
+		for _, code := range d.Codes {
+			if used[i][code] {
+				continue
+			}
+			kept = append(kept, result.LintError{
+				Code:     "W003",
+				Message:  fmt.Sprintf("directive ignoring %s in %s does not suppress anything", code, d.Scope()),
+				Location: fmt.Sprintf("line %d", d.LineNumber),
+				Severity: result.SeverityWarning,
+			})
+		}
+	}
+
+	r.SetIssues(kept)
+}
+
+// containsCode reports whether codes contains code.
+func containsCode(codes []string, code string) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// functionName mirrors the checkers' location naming for unnamed functions.
+func functionName(name string) string {
+	if name == "" {
+		return "(unnamed)"
+	}
+	return name
+}
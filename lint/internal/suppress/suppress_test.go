@@ -0,0 +1,186 @@
+package suppress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+const scopedSpec = `# simplex-lint: ignore W011 -- monolithic legacy spec, split tracked separately
+
+FUNCTION: wide(a, b, c, d, e, f, g) → result
+  # simplex-lint: ignore E011 -- mirrors the upstream API
+
+RULES:
+  # simplex-lint: ignore W010 -- long rule is quoted from policy
+  - return the result
+
+DONE_WHEN:
+  - done
+
+EXAMPLES:
+  (1, 2, 3, 4, 5, 6, 7) → ok
+
+ERRORS:
+  - fail
+
+FUNCTION: other(a, b, c, d, e, f, g) → result
+
+RULES:
+  - return the result
+
+DONE_WHEN:
+  - done
+
+EXAMPLES:
+  (1, 2, 3, 4, 5, 6, 7) → ok
+
+ERRORS:
+  - fail
+`
+
+func parse(t *testing.T, spec string) *parser.ParsedSpec {
+	t.Helper()
+	return parser.NewParser().Parse(spec)
+}
+
+func codes(issues []result.LintError) []string {
+	var out []string
+	for _, e := range issues {
+		out = append(out, e.Code+"@"+e.Location)
+	}
+	return out
+}
+
+func TestParse_Scopes(t *testing.T) {
+	directives, problems := Parse(parse(t, scopedSpec))
+
+	assert.Empty(t, problems)
+	require.Len(t, directives, 3)
+
+	assert.Equal(t, []string{"W011"}, directives[0].Codes)
+	assert.Equal(t, "spec", directives[0].Scope())
+	assert.Equal(t, 1, directives[0].LineNumber)
+
+	assert.Equal(t, []string{"E011"}, directives[1].Codes)
+	assert.Equal(t, "FUNCTION wide", directives[1].Scope())
+	assert.Equal(t, "mirrors the upstream API", directives[1].Reason)
+
+	assert.Equal(t, []string{"W010"}, directives[2].Codes)
+	assert.Equal(t, "FUNCTION wide RULES", directives[2].Scope())
+}
+
+func TestParse_Malformed(t *testing.T) {
+	spec := `FUNCTION: f() → result
+  # simplex-lint: ignore E011
+  // simplex-lint: ignore -- no codes
+  <!-- simplex-lint: disable E010 -- wrong verb -->
+  # simplex-lint: ignore e010, w010 -- lower case is fine -->
+`
+	directives, problems := Parse(parse(t, spec))
+
+	require.Len(t, problems, 3)
+	assert.Contains(t, problems[0].Message, "requires a reason")
+	assert.Equal(t, "line 2", problems[0].Location)
+	assert.Contains(t, problems[1].Message, "at least one rule code")
+	assert.Contains(t, problems[2].Message, "unknown simplex-lint directive")
+	for _, p := range problems {
+		assert.Equal(t, "W002", p.Code)
+	}
+
+	require.Len(t, directives, 1)
+	assert.Equal(t, []string{"E010", "W010"}, directives[0].Codes)
+	assert.Equal(t, "lower case is fine", directives[0].Reason)
+}
+
+func TestApply_SuppressesOnlyWithinScope(t *testing.T) {
+	spec := parse(t, scopedSpec)
+	r := result.NewLintResult("test.md")
+	r.AddError("E011", "too many inputs", "FUNCTION wide")
+	r.AddError("E011", "too many inputs", "FUNCTION other")
+	r.AddWarning("W010", "long rule", "FUNCTION wide RULES")
+	r.AddWarning("W011", "many functions", "spec")
+
+	Apply(spec, r)
+
+	assert.Equal(t, 3, r.Stats.Suppressed)
+	assert.False(t, r.Valid)
+	assert.Equal(t, []string{"E011@FUNCTION other"}, codes(r.Errors))
+	assert.Empty(t, r.Warnings)
+}
+
+func TestApply_LandmarkScopeDoesNotCoverFunctionLevelIssues(t *testing.T) {
+	spec := parse(t, scopedSpec)
+	r := result.NewLintResult("test.md")
+	r.AddError("E011", "too many inputs", "FUNCTION wide")
+	r.AddWarning("W010", "long rule", "FUNCTION wide")
+	r.AddWarning("W011", "many functions", "spec")
+
+	Apply(spec, r)
+
+	require.Len(t, r.Warnings, 2)
+	assert.Equal(t, "W010", r.Warnings[0].Code)
+	assert.Equal(t, "W003", r.Warnings[1].Code)
+	assert.Contains(t, r.Warnings[1].Message, "W010 in FUNCTION wide RULES")
+	assert.Equal(t, "line 7", r.Warnings[1].Location)
+}
+
+func TestApply_ReportsUnusedCodes(t *testing.T) {
+	spec := parse(t, `FUNCTION: f(a) → result
+  # simplex-lint: ignore E011, E010 -- reviewed
+
+RULES:
+  - x
+`)
+	r := result.NewLintResult("test.md")
+	r.AddError("E011", "too many inputs", "FUNCTION f")
+
+	Apply(spec, r)
+
+	assert.True(t, r.Valid)
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W003", r.Warnings[0].Code)
+	assert.Contains(t, r.Warnings[0].Message, "E010")
+}
+
+func TestApply_MalformedDirectiveDoesNotSuppress(t *testing.T) {
+	spec := parse(t, `FUNCTION: f(a) → result
+  # simplex-lint: ignore E011
+`)
+	r := result.NewLintResult("test.md")
+	r.AddError("E011", "too many inputs", "FUNCTION f")
+
+	Apply(spec, r)
+
+	assert.False(t, r.Valid)
+	assert.Equal(t, 0, r.Stats.Suppressed)
+	require.Len(t, r.Warnings, 1)
+	assert.Equal(t, "W002", r.Warnings[0].Code)
+}
+
+func TestApply_NoDirectives(t *testing.T) {
+	spec := parse(t, "FUNCTION: f(a) → result\n")
+	r := result.NewLintResult("test.md")
+	r.AddError("E011", "too many inputs", "FUNCTION f")
+
+	Apply(spec, r)
+
+	assert.Len(t, r.Errors, 1)
+	assert.Empty(t, r.Warnings)
+}
+
+func TestApply_UnnamedFunction(t *testing.T) {
+	spec := parse(t, `FUNCTION:
+  # simplex-lint: ignore E002 -- placeholder while drafting
+`)
+	r := result.NewLintResult("test.md")
+	r.AddError("E002", "missing RULES", "FUNCTION (unnamed)")
+
+	Apply(spec, r)
+
+	assert.True(t, r.Valid)
+	assert.Empty(t, r.Warnings)
+}
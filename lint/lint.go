@@ -2,70 +2,380 @@
 package lint
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
 	"github.com/thinkwright/simplex/lint/internal/checks"
+	"github.com/thinkwright/simplex/lint/internal/config"
 	"github.com/thinkwright/simplex/lint/internal/parser"
+	"github.com/thinkwright/simplex/lint/internal/plugin"
+	"github.com/thinkwright/simplex/lint/internal/preset"
 	"github.com/thinkwright/simplex/lint/internal/result"
+	"github.com/thinkwright/simplex/lint/internal/rules"
+	"github.com/thinkwright/simplex/lint/internal/suppress"
 )
 
 // Result is a linting result for a single spec.
 type Result = result.LintResult
 
-// Error is a single linting issue (error or warning).
+// Error is a single linting issue.
 type Error = result.LintError
 
 // Stats is summary statistics for a linted spec.
 type Stats = result.LintStats
 
-// Config holds configuration for the linter.
+// Spec is a parsed Simplex specification, as seen by checks.
+type Spec = parser.ParsedSpec
+
+// Function is a parsed FUNCTION block with its nested landmarks.
+type Function = parser.FunctionBlock
+
+// Landmark is a parsed landmark block.
+type Landmark = parser.Landmark
+
+// Rule describes a rule code the linter can report.
+type Rule = rules.Rule
+
+// Plugin is an external checker executable (see package plugin for the
+// protocol).
+type Plugin = plugin.Plugin
+
+// Severity levels for issues and rule overrides.
+const (
+	SeverityError   = result.SeverityError
+	SeverityWarning = result.SeverityWarning
+	SeverityInfo    = result.SeverityInfo
+	SeverityHint    = result.SeverityHint
+)
+
+// Function landmark names, for use with Function.GetLandmark.
+const (
+	LandmarkRULES       = parser.LandmarkRULES
+	LandmarkDONE_WHEN   = parser.LandmarkDONE_WHEN
+	LandmarkEXAMPLES    = parser.LandmarkEXAMPLES
+	LandmarkERRORS      = parser.LandmarkERRORS
+	LandmarkREADS       = parser.LandmarkREADS
+	LandmarkWRITES      = parser.LandmarkWRITES
+	LandmarkTRIGGERS    = parser.LandmarkTRIGGERS
+	LandmarkNOT_ALLOWED = parser.LandmarkNOT_ALLOWED
+	LandmarkHANDOFF     = parser.LandmarkHANDOFF
+	LandmarkUNCERTAIN   = parser.LandmarkUNCERTAIN
+	LandmarkDETERMINISM = parser.LandmarkDETERMINISM
+)
+
+// Check is a custom check run alongside the built-in checkers. Run reports
+// issues with the same helpers the built-in checkers use (AddError,
+// AddWarningWithSuggestion, ...), locating them with FunctionLocation so that
+// suppression directives and --changed-since can attribute them.
+type Check struct {
+	Name  string
+	Rules []Rule // codes the check reports, listed by Linter.Rules
+	Run   func(spec *Spec, r *Result)
+}
+
+// Preset names, for Config.Preset.
+const (
+	PresetRecommended = preset.Recommended
+	PresetStrict      = preset.Strict
+	PresetCompatV03   = preset.CompatV03
+)
+
+// Config holds configuration for the linter. It is the single set of options
+// shared by the CLI (flags and .simplex-lint.yaml), embedders and the
+// /api/lint endpoint, which decodes it from the request body. Zero values
+// mean "use the preset's value", which defaults to the built-in default.
 type Config struct {
-	MaxRules  int
-	MaxInputs int
+	// Preset is the rule bundle the options below are layered on
+	// (default: recommended).
+	Preset string `json:"preset,omitempty"`
+
+	// StatusPresets maps a spec's front-matter status to the preset used
+	// instead of Preset. nil uses the default mapping (draft → recommended,
+	// release → strict, legacy → compat-v0.3); an empty map turns it off.
+	StatusPresets map[string]string `json:"status_presets,omitempty"`
+
+	MaxRules      int `json:"max_rules,omitempty"`       // max RULES items (default: 15)
+	MaxInputs     int `json:"max_inputs,omitempty"`      // max function inputs (default: 6)
+	MaxRuleLength int `json:"max_rule_length,omitempty"` // max characters per RULES item (default: 200)
+	MaxFunctions  int `json:"max_functions,omitempty"`   // FUNCTION count that triggers W011 (default: 10)
+	MaxEvalK      int `json:"max_eval_k,omitempty"`      // largest plausible k in pass^k and pass@k (default: 100)
+
+	// SpecVersion selects which landmark checks run (default: latest).
+	SpecVersion string `json:"spec_version,omitempty"`
+
+	// DisabledRules and Severity apply to built-in and custom codes alike.
+	// EnabledRules turns on opt-in rules and wins over DisabledRules and
+	// the preset.
+	EnabledRules  []string          `json:"enable,omitempty"`
+	DisabledRules []string          `json:"disable,omitempty"`
+	Severity      map[string]string `json:"severity,omitempty"` // rule code → "error", "warning", "info" or "hint"
+
+	// Terms replace the phrase lists of the wording checks.
+	Terms Terms `json:"terms,omitempty"`
+
+	// BuiltinTypes are the type names that need no DATA definition, such
+	// as "result" in "→ result". Nil keeps the defaults; a list replaces
+	// them. Primitive types such as string and integer are always built in.
+	BuiltinTypes []string `json:"builtin_types,omitempty"`
+
+	// DataFlow configures the READS/WRITES/TRIGGERS graph checks.
+	DataFlow DataFlow `json:"data_flow,omitempty"`
+
+	// Checks are custom checks run after the built-in ones.
+	Checks []Check `json:"-"`
+
+	// Plugins are external checkers run after Checks. They execute local
+	// programs, so they can only be set in code or a config file.
+	Plugins []Plugin `json:"-"`
+
+	// Timings records how long each check took in Stats.Timings.
+	Timings bool `json:"timings,omitempty"`
+}
+
+// Terms are the phrase lists the wording checks match. Entries are phrases,
+// matched case-insensitively on word boundaries, or regular expressions
+// between slashes, e.g. "/\binternal \w+/". A nil list keeps the built-in
+// terms; an empty one turns them off.
+type Terms struct {
+	NotObservable []string `json:"not_observable,omitempty"` // DONE_WHEN terms reported as E030
+	Vague         []string `json:"vague,omitempty"`          // DONE_WHEN terms reported as W030
+	Observable    []string `json:"observable,omitempty"`     // terms that make a vague DONE_WHEN item observable
+	Procedural    []string `json:"procedural,omitempty"`     // RULES terms reported as E040 or E041
+	CatchAll      []string `json:"catch_all,omitempty"`      // ERRORS conditions that satisfy W110
+}
+
+// DataFlow configures the data-flow graph checks.
+type DataFlow struct {
+	// External lists key prefixes produced or consumed outside the specs,
+	// e.g. "env.". Matching keys are exempt from E100, E101 and W100.
+	External []string `json:"external,omitempty"`
+}
+
+// Validate reports the first invalid option, for callers that take options
+// from untrusted input.
+func (c Config) Validate() error {
+	if c.MaxRules < 0 || c.MaxInputs < 0 || c.MaxRuleLength < 0 || c.MaxFunctions < 0 || c.MaxEvalK < 0 {
+		return fmt.Errorf("thresholds must not be negative")
+	}
+	if c.SpecVersion != "" && !config.IsSpecVersion(c.SpecVersion) {
+		return fmt.Errorf("spec_version must be one of %s, got: %s",
+			strings.Join(config.SpecVersions, ", "), c.SpecVersion)
+	}
+	for code, s := range c.Severity {
+		if !result.IsSeverity(strings.ToLower(s)) {
+			return fmt.Errorf("severity for %s must be error, warning, info or hint, got: %s", code, s)
+		}
+	}
+	if c.Preset != "" {
+		if _, ok := preset.Get(c.Preset); !ok {
+			return fmt.Errorf("preset must be one of %s, got: %s", strings.Join(preset.Names(), ", "), c.Preset)
+		}
+	}
+	for status, name := range c.StatusPresets {
+		if _, ok := preset.Get(name); !ok {
+			return fmt.Errorf("status %s: preset must be one of %s, got: %s", status, strings.Join(preset.Names(), ", "), name)
+		}
+	}
+	return config.ValidateTerms(config.Terms(c.Terms))
 }
 
 // Linter performs linting on Simplex specifications.
 type Linter struct {
-	parser             *parser.Parser
-	structuralChecker  *checks.StructuralChecker
-	complexityChecker  *checks.ComplexityChecker
-	evolutionChecker   *checks.EvolutionChecker
-	determinismChecker *checks.DeterminismChecker
-	config             Config
+	parser               *parser.Parser
+	structuralChecker    *checks.StructuralChecker
+	complexityChecker    *checks.ComplexityChecker
+	evolutionChecker     *checks.EvolutionChecker
+	determinismChecker   *checks.DeterminismChecker
+	schemaChecker        *checks.SchemaChecker
+	dataFlowChecker      *checks.DataFlowChecker
+	observabilityChecker *checks.ObservabilityChecker
+	behavioralChecker    *checks.BehavioralChecker
+	errorsChecker        *checks.ErrorsChecker
+	preset               preset.Preset
+	specVersion          string
+	disabled             map[string]bool   // upper-case codes turned off by the preset and config
+	severity             map[string]string // preset and config severities with upper-case codes
+	statusPresets        map[string]string // lower-case front-matter status → preset name
+	config               Config
+
+	mu       sync.Mutex
+	byStatus map[string]*Linter // linters for presets selected by front-matter status
+}
+
+// New creates a new Linter with the given configuration. An unknown preset
+// falls back to recommended; use Config.Validate to reject it instead.
+func New(cfg Config) *Linter {
+	p, ok := preset.Get(cfg.Preset)
+	if !ok {
+		p, _ = preset.Get(preset.Recommended)
+	}
+	return newLinter(cfg, p)
 }
 
-// New creates a new Linter with the given configuration.
-func New(config Config) *Linter {
+// newLinter creates a Linter that layers cfg on top of preset p.
+func newLinter(cfg Config, p preset.Preset) *Linter {
 	complexityConfig := checks.DefaultComplexityConfig()
-	if config.MaxRules > 0 {
-		complexityConfig.MaxRules = config.MaxRules
+	complexityConfig.MaxRules = firstPositive(cfg.MaxRules, p.MaxRules, complexityConfig.MaxRules)
+	complexityConfig.MaxInputs = firstPositive(cfg.MaxInputs, p.MaxInputs, complexityConfig.MaxInputs)
+	complexityConfig.MaxRuleLength = firstPositive(cfg.MaxRuleLength, p.MaxRuleLength, complexityConfig.MaxRuleLength)
+	complexityConfig.MaxFunctions = firstPositive(cfg.MaxFunctions, p.MaxFunctions, complexityConfig.MaxFunctions)
+
+	evolutionConfig := checks.DefaultEvolutionConfig()
+	evolutionConfig.MaxK = firstPositive(cfg.MaxEvalK, evolutionConfig.MaxK)
+
+	observabilityConfig := checks.DefaultObservabilityConfig()
+	if cfg.Terms.NotObservable != nil {
+		observabilityConfig.NotObservable = cfg.Terms.NotObservable
+	}
+	if cfg.Terms.Vague != nil {
+		observabilityConfig.Vague = cfg.Terms.Vague
+	}
+	if cfg.Terms.Observable != nil {
+		observabilityConfig.Observable = cfg.Terms.Observable
 	}
-	if config.MaxInputs > 0 {
-		complexityConfig.MaxInputs = config.MaxInputs
+	behavioralConfig := checks.DefaultBehavioralConfig()
+	if cfg.Terms.Procedural != nil {
+		behavioralConfig.Procedural = cfg.Terms.Procedural
+	}
+
+	errorsConfig := checks.DefaultErrorsConfig()
+	if cfg.Terms.CatchAll != nil {
+		errorsConfig.CatchAll = cfg.Terms.CatchAll
+	}
+
+	specVersion := cfg.SpecVersion
+	if specVersion == "" {
+		specVersion = p.SpecVersion
+	}
+	if specVersion == "" {
+		specVersion = config.DefaultSpecVersion
+	}
+
+	disabled := make(map[string]bool)
+	if !p.EnableOptIn {
+		for _, code := range rules.OptIn() {
+			disabled[code] = true
+		}
+	}
+	for _, code := range append(p.Disable, cfg.DisabledRules...) {
+		disabled[strings.ToUpper(code)] = true
+	}
+	for _, code := range cfg.EnabledRules {
+		delete(disabled, strings.ToUpper(code))
+	}
+
+	severity := make(map[string]string, len(p.Severity)+len(cfg.Severity))
+	for _, m := range []map[string]string{p.Severity, cfg.Severity} {
+		for code, s := range m {
+			severity[strings.ToUpper(code)] = strings.ToLower(s)
+		}
+	}
+
+	statusPresets := cfg.StatusPresets
+	if statusPresets == nil {
+		statusPresets = preset.StatusPresets()
+	}
+	normalized := make(map[string]string, len(statusPresets))
+	for status, name := range statusPresets {
+		normalized[strings.ToLower(strings.TrimSpace(status))] = name
 	}
 
 	return &Linter{
-		parser:             parser.NewParser(),
-		structuralChecker:  checks.NewStructuralChecker(),
-		complexityChecker:  checks.NewComplexityCheckerWithConfig(complexityConfig),
-		evolutionChecker:   checks.NewEvolutionChecker(),
-		determinismChecker: checks.NewDeterminismChecker(),
-		config:             config,
+		parser: parser.NewParser(),
+		structuralChecker: checks.NewStructuralCheckerWithConfig(checks.StructuralConfig{
+			OutputSchemas: config.AtLeast(specVersion, config.SpecVersion05),
+			BuiltinTypes:  cfg.BuiltinTypes,
+		}),
+		complexityChecker:    checks.NewComplexityCheckerWithConfig(complexityConfig),
+		evolutionChecker:     checks.NewEvolutionCheckerWithConfig(evolutionConfig),
+		determinismChecker:   checks.NewDeterminismChecker(),
+		schemaChecker:        checks.NewSchemaCheckerWithConfig(checks.SchemaConfig{BuiltinTypes: cfg.BuiltinTypes}),
+		dataFlowChecker:      checks.NewDataFlowCheckerWithConfig(checks.DataFlowConfig{External: cfg.DataFlow.External}),
+		observabilityChecker: checks.NewObservabilityCheckerWithConfig(observabilityConfig),
+		behavioralChecker:    checks.NewBehavioralCheckerWithConfig(behavioralConfig),
+		errorsChecker:        checks.NewErrorsCheckerWithConfig(errorsConfig),
+		preset:               p,
+		specVersion:          specVersion,
+		disabled:             disabled,
+		severity:             severity,
+		statusPresets:        normalized,
+		config:               cfg,
+		byStatus:             make(map[string]*Linter),
+	}
+}
+
+// firstPositive returns the first value greater than zero, or 0.
+func firstPositive(values ...int) int {
+	for _, v := range values {
+		if v > 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+// forSpec returns the linter for the preset the spec's front-matter status
+// selects, or l when it selects none or l's own preset.
+func (l *Linter) forSpec(spec *Spec) *Linter {
+	status := strings.ToLower(strings.TrimSpace(spec.FrontMatter["status"]))
+	name, ok := l.statusPresets[status]
+	if status == "" || !ok {
+		return l
+	}
+	p, ok := preset.Get(name)
+	if !ok || p.Name == l.preset.Name {
+		return l
 	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if derived, ok := l.byStatus[p.Name]; ok {
+		return derived
+	}
+	derived := newLinter(l.config, p)
+	l.byStatus[p.Name] = derived
+	return derived
+}
+
+// Parse parses a spec without linting it.
+func Parse(content string) *Spec {
+	return parser.NewParser().Parse(content)
 }
 
 // Lint validates a Simplex spec and returns the result.
 func (l *Linter) Lint(name, content string) *Result {
-	r := result.NewLintResult(name)
+	return l.LintContext(context.Background(), name, content)
+}
 
+// LintContext is Lint with cancellation. When ctx is done, checks that have
+// not finished are abandoned and reported as E091, and the partial result is
+// returned.
+func (l *Linter) LintContext(ctx context.Context, name, content string) *Result {
 	spec := l.parser.Parse(content)
+	return l.forSpec(spec).lint(ctx, name, spec, nil)
+}
+
+// lint runs the checks and rule configuration on a parsed spec. A non-nil
+// graph replaces the single-spec data-flow check.
+func (l *Linter) lint(ctx context.Context, name string, spec *Spec, graph *FlowGraph) *Result {
+	r := result.NewLintResult(name)
+	r.Preset = l.preset.Name
 
 	for _, w := range spec.ParseWarnings {
 		r.AddWarning("W001", w, "parse")
 	}
 
-	l.structuralChecker.Check(spec, r)
-	l.complexityChecker.Check(spec, r)
-	l.evolutionChecker.Check(spec, r)
-	l.determinismChecker.Check(spec, r)
+	l.runStages(ctx, l.stages(name, graph), spec, r)
+
+	// Apply inline suppression directives, then rule configuration
+	suppress.Apply(spec, r)
+	r.ApplyRuleOverrides(l.disabled, l.severity)
+	if l.preset.PromoteWarnings {
+		r.PromoteWarnings(l.severity)
+	}
+	r.AssignFingerprints()
 
 	r.Stats.Functions = len(spec.Functions)
 	r.Stats.Examples = l.countTotalExamples(spec)
@@ -74,10 +384,47 @@ func (l *Linter) Lint(name, content string) *Result {
 	if r.Stats.Branches > 0 {
 		r.Stats.CoveragePercent = float64(r.Stats.Examples) / float64(r.Stats.Branches) * 100
 	}
+	if config.AtLeast(l.specVersion, config.SpecVersion04) {
+		r.Stats.Evolution = evolutionCoverage(spec)
+	}
+	r.Stats.RecursiveTypes = checks.RecursiveTypes(checks.ParseDataTypes(spec.DataBlocks))
 
 	return r
 }
 
+// Rules lists the built-in rules and those declared by custom checks,
+// sorted by code.
+func (l *Linter) Rules() []Rule {
+	lists := [][]Rule{rules.Builtin()}
+	for _, c := range l.config.Checks {
+		custom := make([]Rule, len(c.Rules))
+		for i, r := range c.Rules {
+			if r.Category == "" {
+				r.Category = c.Name
+			}
+			if r.Severity == "" {
+				r.Severity = SeverityWarning
+			}
+			custom[i] = r
+		}
+		lists = append(lists, custom)
+	}
+	return rules.Merge(lists...)
+}
+
+// FunctionLocation returns the issue location for a function, optionally
+// narrowed to one of its landmarks, e.g. "FUNCTION charge NOT_ALLOWED".
+func FunctionLocation(fn *Function, landmark string) string {
+	name := fn.Name
+	if name == "" {
+		name = "(unnamed)"
+	}
+	if landmark == "" {
+		return "FUNCTION " + name
+	}
+	return "FUNCTION " + name + " " + landmark
+}
+
 func (l *Linter) countTotalExamples(spec *parser.ParsedSpec) int {
 	total := 0
 	for _, fn := range spec.Functions {
@@ -98,6 +445,34 @@ func (l *Linter) countTotalBranches(spec *parser.ParsedSpec) int {
 	return total
 }
 
+// evolutionCoverage counts the BASELINE items of all functions and those an
+// example exercises, or returns nil if there are none.
+func evolutionCoverage(spec *parser.ParsedSpec) *result.EvolutionCoverage {
+	var cov result.EvolutionCoverage
+	for _, fn := range spec.Functions {
+		if !fn.HasBaseline() || fn.GetExamples() == "" {
+			continue
+		}
+		for _, item := range checks.BaselineCoverage(fn) {
+			covered := 0
+			if len(item.Examples) > 0 {
+				covered = 1
+			}
+			if item.Kind == "preserve" {
+				cov.Preserve++
+				cov.PreserveCovered += covered
+			} else {
+				cov.Evolve++
+				cov.EvolveCovered += covered
+			}
+		}
+	}
+	if cov.Preserve+cov.Evolve == 0 {
+		return nil
+	}
+	return &cov
+}
+
 // DefaultLinter creates a linter with default settings.
 func DefaultLinter() *Linter {
 	return New(Config{})
@@ -0,0 +1,1079 @@
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+const paymentSpec = `FUNCTION: charge(card, amount) → receipt
+
+RULES:
+  - charge the card
+
+DONE_WHEN:
+  - card charged
+
+EXAMPLES:
+  (card, 10) → receipt
+
+ERRORS:
+  - any unhandled condition → fail
+`
+
+// notAllowedCheck requires payment functions to declare NOT_ALLOWED.
+var notAllowedCheck = Check{
+	Name:  "payments",
+	Rules: []Rule{{Code: "PAY001", Title: "Payment function missing NOT_ALLOWED", Severity: SeverityError}},
+	Run: func(spec *Spec, r *Result) {
+		for i := range spec.Functions {
+			fn := &spec.Functions[i]
+			if fn.Name == "charge" && !fn.HasLandmark(LandmarkNOT_ALLOWED) {
+				r.AddErrorWithSuggestion("PAY001", "payment function has no NOT_ALLOWED",
+					FunctionLocation(fn, ""), "add NOT_ALLOWED listing forbidden side effects", false)
+			}
+		}
+	},
+}
+
+func TestLinter_CustomCheck(t *testing.T) {
+	r := New(Config{Checks: []Check{notAllowedCheck}}).Lint("pay.md", paymentSpec)
+
+	assert.False(t, r.Valid)
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "PAY001", r.Errors[0].Code)
+	assert.Equal(t, "FUNCTION charge", r.Errors[0].Location)
+	require.NotNil(t, r.Errors[0].Suggestion)
+	assert.NotEmpty(t, r.Errors[0].Fingerprint)
+}
+
+func TestLinter_CustomCheckFollowsRuleConfig(t *testing.T) {
+	relevelled := New(Config{
+		Checks:   []Check{notAllowedCheck},
+		Severity: map[string]string{"pay001": "Info"},
+	}).Lint("pay.md", paymentSpec)
+
+	assert.True(t, relevelled.Valid)
+	require.Len(t, relevelled.Infos, 1)
+	assert.Equal(t, "PAY001", relevelled.Infos[0].Code)
+
+	disabled := New(Config{
+		Checks:        []Check{notAllowedCheck},
+		DisabledRules: []string{"PAY001"},
+	}).Lint("pay.md", paymentSpec)
+
+	assert.True(t, disabled.Valid)
+	assert.Empty(t, disabled.Issues())
+}
+
+func TestLinter_CustomCheckHonorsSuppression(t *testing.T) {
+	spec := "# simplex-lint: ignore PAY001 -- refunds are handled by the ledger service\n" + paymentSpec
+
+	r := New(Config{Checks: []Check{notAllowedCheck}}).Lint("pay.md", spec)
+
+	assert.True(t, r.Valid)
+	assert.Equal(t, 1, r.Stats.Suppressed)
+}
+
+func TestLinter_Rules(t *testing.T) {
+	list := New(Config{Checks: []Check{notAllowedCheck}}).Rules()
+
+	var codes []string
+	for _, r := range list {
+		codes = append(codes, r.Code)
+	}
+	assert.Contains(t, codes, "E001")
+	assert.Contains(t, codes, "W011")
+	assert.Contains(t, codes, "PAY001")
+	assert.IsIncreasing(t, codes)
+
+	for _, r := range list {
+		if r.Code == "PAY001" {
+			assert.Equal(t, "payments", r.Category)
+			assert.Equal(t, SeverityError, r.Severity)
+		}
+	}
+}
+
+// TestLinter_RuleSnippets checks that every catalog snippet shown by
+// simplex-lint explain does what it claims under the default config, with
+// opt-in rules enabled.
+func TestLinter_RuleSnippets(t *testing.T) {
+	var optIn []string
+	for _, rule := range DefaultLinter().Rules() {
+		if rule.OptIn {
+			optIn = append(optIn, rule.Code)
+		}
+	}
+	require.NotEmpty(t, optIn)
+
+	linter := New(Config{EnabledRules: optIn})
+	for _, rule := range linter.Rules() {
+		if rule.Bad != "" {
+			assert.Contains(t, codesOf(linter.Lint("bad.md", rule.Bad)), rule.Code, "%s bad snippet", rule.Code)
+		}
+		if rule.Good != "" {
+			assert.NotContains(t, codesOf(linter.Lint("good.md", rule.Good)), rule.Code, "%s good snippet", rule.Code)
+		}
+	}
+}
+
+func codesOf(r *Result) []string {
+	var codes []string
+	for _, e := range r.Issues() {
+		codes = append(codes, e.Code)
+	}
+	return codes
+}
+
+func TestFunctionLocation(t *testing.T) {
+	assert.Equal(t, "FUNCTION charge RULES", FunctionLocation(&Function{Name: "charge"}, LandmarkRULES))
+	assert.Equal(t, "FUNCTION (unnamed)", FunctionLocation(&Function{}, ""))
+}
+
+func TestNewLinter(t *testing.T) {
+	cfg := Config{
+		MaxRules:  20,
+		MaxInputs: 8,
+	}
+
+	linter := New(cfg)
+
+	assert.NotNil(t, linter)
+	assert.NotNil(t, linter.parser)
+	assert.NotNil(t, linter.structuralChecker)
+	assert.NotNil(t, linter.complexityChecker)
+	assert.Equal(t, cfg, linter.config)
+}
+
+func TestNewLinter_DefaultConfig(t *testing.T) {
+	linter := New(Config{})
+
+	assert.NotNil(t, linter)
+	// With zero config, defaults should be applied
+}
+
+func TestLinter_Lint_ValidSpec(t *testing.T) {
+	linter := New(Config{})
+
+	name := "valid.md"
+	content := `FUNCTION: add(a, b) → sum
+
+RULES:
+  - return the sum of a and b
+
+DONE_WHEN:
+  - result equals a + b
+
+EXAMPLES:
+  (2, 3) → 5
+
+ERRORS:
+  - any error → fail`
+
+	result := linter.Lint(name, content)
+
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "valid.md", result.File)
+	assert.Equal(t, 1, result.Stats.Functions)
+	assert.Equal(t, 1, result.Stats.Examples)
+}
+
+func TestLinter_Lint_InvalidSpec_MissingFunction(t *testing.T) {
+	linter := New(Config{})
+
+	name := "invalid.md"
+	content := `DATA: SomeType\n  field: string`
+
+	result := linter.Lint(name, content)
+
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "E001", result.Errors[0].Code)
+}
+
+func TestLinter_Lint_InvalidSpec_MissingErrors(t *testing.T) {
+	linter := New(Config{})
+
+	name := "missing_errors.md"
+	content := `FUNCTION: test() → result
+
+RULES:
+  - do something
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  () → ok`
+
+	result := linter.Lint(name, content)
+
+	assert.False(t, result.Valid)
+	hasE005 := false
+	for _, e := range result.Errors {
+		if e.Code == "E005" {
+			hasE005 = true
+		}
+	}
+	assert.True(t, hasE005, "Expected E005 for missing ERRORS")
+}
+
+func TestLinter_Lint_ComplexityViolations(t *testing.T) {
+	linter := New(Config{
+		MaxRules:  3,
+		MaxInputs: 2,
+	})
+
+	name := "complex.md"
+	content := `FUNCTION: complex(a, b, c, d) → result
+
+RULES:
+  - rule 1
+  - rule 2
+  - rule 3
+  - rule 4
+  - rule 5
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (1, 2, 3, 4) → ok
+
+ERRORS:
+  - any unhandled condition → fail`
+
+	result := linter.Lint(name, content)
+
+	assert.False(t, result.Valid)
+
+	codes := make(map[string]bool)
+	for _, e := range result.Errors {
+		codes[e.Code] = true
+	}
+	assert.True(t, codes["E010"], "Expected E010 for too many rules")
+	assert.True(t, codes["E011"], "Expected E011 for too many inputs")
+}
+
+func TestLinter_Lint_ParseWarnings(t *testing.T) {
+	linter := New(Config{})
+
+	name := "warnings.md"
+	content := `FUNCTION: test() → result
+
+RULES:
+  - do something
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  () → ok
+
+ERRORS:
+  - any unhandled condition → fail
+
+CUSTOM_UNKNOWN_LANDMARK:
+  - this is unrecognized`
+
+	result := linter.Lint(name, content)
+
+	// Should still be valid (unrecognized landmarks are warnings)
+	assert.True(t, result.Valid)
+	assert.NotEmpty(t, result.Warnings)
+
+	hasW001 := false
+	for _, w := range result.Warnings {
+		if w.Code == "W001" {
+			hasW001 = true
+		}
+	}
+	assert.True(t, hasW001, "Expected W001 for unrecognized landmark")
+}
+
+func TestLinter_Lint_Stats(t *testing.T) {
+	linter := New(Config{})
+
+	name := "stats.md"
+	content := `FUNCTION: fn1(x) → result
+
+RULES:
+  - if A, do X
+  - if B, do Y
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (A) → X
+  (B) → Y
+  (C) → Z
+
+ERRORS:
+  - any unhandled condition → fail
+
+FUNCTION: fn2() → result
+
+RULES:
+  - simple rule
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  () → ok
+
+ERRORS:
+  - any unhandled condition → fail`
+
+	result := linter.Lint(name, content)
+
+	assert.True(t, result.Valid)
+	assert.Equal(t, 2, result.Stats.Functions)
+	assert.Equal(t, 4, result.Stats.Examples) // 3 + 1
+	assert.True(t, result.Stats.Branches > 0)
+	assert.True(t, result.Stats.CoveragePercent > 0)
+}
+
+func TestLinter_Lint_CoveragePercent_CanExceed100(t *testing.T) {
+	linter := New(Config{})
+
+	// More examples than branches — coverage should exceed 100%
+	name := "overcovered.md"
+	content := `FUNCTION: test(n) → result
+
+RULES:
+  - simple rule with no branches
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (1) → a
+  (2) → b
+  (3) → c
+  (4) → d
+  (5) → e
+
+ERRORS:
+  - any unhandled condition → fail`
+
+	result := linter.Lint(name, content)
+
+	assert.True(t, result.Valid)
+	// 5 examples / 1 branch = 500%
+	assert.Equal(t, 500.0, result.Stats.CoveragePercent)
+}
+
+// Integration tests using actual test fixtures
+func TestIntegration_ValidMinimal(t *testing.T) {
+	content, err := os.ReadFile("testdata/valid_minimal.md")
+	require.NoError(t, err)
+
+	linter := New(Config{})
+	result := linter.Lint("valid_minimal.md", string(content))
+
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+}
+
+func TestIntegration_ValidComplex(t *testing.T) {
+	content, err := os.ReadFile("testdata/valid_complex.md")
+	require.NoError(t, err)
+
+	linter := New(Config{})
+	result := linter.Lint("valid_complex.md", string(content))
+
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 3, result.Stats.Functions)
+}
+
+func TestIntegration_InvalidMissingErrors(t *testing.T) {
+	content, err := os.ReadFile("testdata/invalid_missing_errors.md")
+	require.NoError(t, err)
+
+	linter := New(Config{})
+	result := linter.Lint("invalid_missing_errors.md", string(content))
+
+	assert.False(t, result.Valid)
+	hasE005 := false
+	for _, e := range result.Errors {
+		if e.Code == "E005" {
+			hasE005 = true
+		}
+	}
+	assert.True(t, hasE005)
+}
+
+func TestIntegration_InvalidMissingFunction(t *testing.T) {
+	content, err := os.ReadFile("testdata/invalid_missing_function.md")
+	require.NoError(t, err)
+
+	linter := New(Config{})
+	result := linter.Lint("invalid_missing_function.md", string(content))
+
+	assert.False(t, result.Valid)
+	hasE001 := false
+	for _, e := range result.Errors {
+		if e.Code == "E001" {
+			hasE001 = true
+		}
+	}
+	assert.True(t, hasE001)
+}
+
+func TestIntegration_InvalidTooComplex(t *testing.T) {
+	content, err := os.ReadFile("testdata/invalid_too_complex.md")
+	require.NoError(t, err)
+
+	linter := New(Config{})
+	result := linter.Lint("invalid_too_complex.md", string(content))
+
+	assert.False(t, result.Valid)
+
+	codes := make(map[string]bool)
+	for _, e := range result.Errors {
+		codes[e.Code] = true
+	}
+	assert.True(t, codes["E010"], "Expected E010")
+	assert.True(t, codes["E011"], "Expected E011")
+}
+
+func TestIntegration_InvalidUncoveredBranch(t *testing.T) {
+	content, err := os.ReadFile("testdata/invalid_uncovered_branch.md")
+	require.NoError(t, err)
+
+	linter := New(Config{})
+	result := linter.Lint("invalid_uncovered_branch.md", string(content))
+
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "E020", result.Errors[0].Code)
+	assert.Contains(t, result.Errors[0].Message, "'the destination is international'")
+	assert.Equal(t, "FUNCTION shipping_cost RULES", result.Errors[0].Location)
+}
+
+func TestIntegration_InvalidProcedural(t *testing.T) {
+	content, err := os.ReadFile("testdata/invalid_procedural.md")
+	require.NoError(t, err)
+
+	linter := New(Config{})
+	result := linter.Lint("invalid_procedural.md", string(content))
+
+	assert.False(t, result.Valid)
+	assert.Equal(t, []string{"E040", "E040", "E040"}, codesOf(result))
+	assert.Contains(t, result.Errors[1].Message, `RULES item 2 is procedural: "[loop] through items`)
+}
+
+func TestIntegration_InvalidOutputSchema(t *testing.T) {
+	content, err := os.ReadFile("testdata/invalid_output_schema.md")
+	require.NoError(t, err)
+
+	linter := New(Config{EnabledRules: []string{"W080"}})
+	result := linter.Lint("invalid_output_schema.md", string(content))
+
+	assert.False(t, result.Valid)
+	assert.ElementsMatch(t, []string{"E081", "E082", "W080"}, codesOf(result))
+	assert.Contains(t, result.Errors[0].Message, "example 2 output missing required field 'total' of Receipt")
+}
+
+func TestIntegration_InvalidDataFlow(t *testing.T) {
+	content, err := os.ReadFile("testdata/invalid_dataflow.md")
+	require.NoError(t, err)
+
+	result := New(Config{}).Lint("invalid_dataflow.md", string(content))
+
+	assert.False(t, result.Valid)
+	assert.Equal(t, []string{"E101", "W100"}, codesOf(result))
+	assert.Equal(t, "FUNCTION deploy TRIGGERS", result.Errors[0].Location)
+	assert.Contains(t, result.Warnings[0].Message, "'status.compile'")
+
+	result = New(Config{DataFlow: DataFlow{External: []string{"status."}}}).Lint("invalid_dataflow.md", string(content))
+	assert.True(t, result.Valid)
+	assert.Empty(t, codesOf(result))
+}
+
+func TestIntegration_MultiDataFlow(t *testing.T) {
+	files := []string{"auth.md", "compile.md", "deploy.md"}
+	contents := make(map[string]string)
+	graph := NewFlowGraph()
+	for _, name := range files {
+		content, err := os.ReadFile(filepath.Join("testdata", "multi", name))
+		require.NoError(t, err)
+		contents[name] = string(content)
+		graph.Add(name, Parse(string(content)))
+	}
+
+	// Alone, deploy's reads and trigger dangle
+	alone := New(Config{}).Lint("deploy.md", contents["deploy.md"])
+	assert.Equal(t, []string{"E100", "E100", "E101", "W100"}, codesOf(alone))
+
+	// Across files, each remaining finding lands in the file that declares it
+	linter := New(Config{})
+	results := make(map[string]*Result)
+	for _, name := range files {
+		results[name] = linter.LintGraphContext(context.Background(), graph, name, contents[name])
+	}
+	assert.Equal(t, []string{"E100"}, codesOf(results["auth.md"]))
+	assert.Equal(t, "FUNCTION login READS", results["auth.md"].Errors[0].Location)
+	assert.Contains(t, results["auth.md"].Errors[0].Message, "'user.credentials'")
+	assert.Empty(t, codesOf(results["compile.md"]))
+	assert.Equal(t, []string{"W100"}, codesOf(results["deploy.md"]))
+	assert.Equal(t, "FUNCTION notify WRITES", results["deploy.md"].Warnings[0].Location)
+
+	// Each file's linter applies its own external prefixes
+	external := New(Config{DataFlow: DataFlow{External: []string{"user."}}})
+	assert.Empty(t, codesOf(external.LintGraphContext(context.Background(), graph, "auth.md", contents["auth.md"])))
+
+	// A check cut short by its context is redone on the next use
+	fresh := NewFlowGraph()
+	for _, name := range files {
+		fresh.Add(name, Parse(contents[name]))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Empty(t, fresh.findingsFor(ctx, "auth.md", nil))
+	assert.Len(t, fresh.findingsFor(context.Background(), "auth.md", nil), 1)
+}
+
+func TestIntegration_AllTestdata(t *testing.T) {
+	// Test that all testdata files can be processed without panics
+	files, err := filepath.Glob("testdata/*.md")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	linter := New(Config{})
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			// Should not panic
+			result := linter.Lint(filepath.Base(file), string(content))
+
+			// Valid files should pass, invalid files should fail
+			if strings.HasPrefix(filepath.Base(file), "valid_") {
+				assert.True(t, result.Valid, "Expected %s to be valid", file)
+			} else if strings.HasPrefix(filepath.Base(file), "invalid_") {
+				assert.False(t, result.Valid, "Expected %s to be invalid", file)
+			}
+		})
+	}
+}
+
+func TestLinter_Lint_ZeroBranches(t *testing.T) {
+	// Test with a spec that has no identifiable branches
+	linter := New(Config{})
+
+	name := "no_branches.md"
+	content := `FUNCTION: simple() → result
+
+RULES:
+  - just do it
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  () → ok
+
+ERRORS:
+  - any unhandled condition → fail`
+
+	result := linter.Lint(name, content)
+	assert.True(t, result.Valid)
+	// With 1 branch (minimum) and 1 example, coverage should be 100%
+	assert.Equal(t, 1, result.Stats.Branches)
+}
+
+func TestLinter_Lint_EmptySpec(t *testing.T) {
+	linter := New(Config{})
+
+	name := "empty.md"
+	content := ""
+
+	result := linter.Lint(name, content)
+	assert.False(t, result.Valid)
+	assert.Equal(t, 0, result.Stats.Functions)
+	assert.Equal(t, 0, result.Stats.Branches)
+	assert.Equal(t, 0, result.Stats.Examples)
+}
+
+func TestLinter_Lint_RuleOverrides(t *testing.T) {
+	linter := New(Config{
+		MaxInputs:     2,
+		DisabledRules: []string{"E011"},
+		Severity:      map[string]string{"E010": "warning"},
+		MaxRules:      1,
+	})
+
+	result := linter.Lint("overrides.md", `FUNCTION: f(a, b, c) → result
+
+RULES:
+  - rule 1
+  - rule 2
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (1, 2, 3) → ok
+
+ERRORS:
+  - any unhandled condition → fail`)
+
+	assert.True(t, result.Valid)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "E010", result.Warnings[0].Code)
+}
+
+func TestLinter_Lint_MaxRuleLengthAndFunctions(t *testing.T) {
+	linter := New(Config{MaxRuleLength: 10, MaxFunctions: 1})
+
+	result := linter.Lint("thresholds.md", `FUNCTION: a() → result
+
+RULES:
+  - this rule is longer than ten characters
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  () → ok
+
+ERRORS:
+  - any unhandled condition → fail
+
+FUNCTION: b() → result
+
+RULES:
+  - short
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  () → ok
+
+ERRORS:
+  - any unhandled condition → fail`)
+
+	codes := make(map[string]bool)
+	for _, w := range result.Warnings {
+		codes[w.Code] = true
+	}
+	assert.True(t, codes["W010"], "Expected W010 with max rule length 10")
+	assert.True(t, codes["W011"], "Expected W011 with max functions 1")
+}
+
+func TestLinter_Lint_SpecVersionGatesChecks(t *testing.T) {
+	spec := `FUNCTION: f(x) → result
+
+RULES:
+  - do it
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (1) → ok
+
+ERRORS:
+  - any unhandled condition → fail
+
+DETERMINISM:
+  level: fuzzy
+
+BASELINE:
+  reference: "v1"`
+
+	v03 := New(Config{SpecVersion: "0.3"}).Lint("v.md", spec)
+	assert.True(t, v03.Valid, "v0.3 has no BASELINE or DETERMINISM checks")
+
+	v04 := New(Config{SpecVersion: "0.4"}).Lint("v.md", spec)
+	codes := make(map[string]bool)
+	for _, e := range v04.Errors {
+		codes[e.Code] = true
+	}
+	assert.True(t, codes["E060"])
+	assert.False(t, codes["E070"])
+
+	v05 := New(Config{}).Lint("v.md", spec)
+	codes = make(map[string]bool)
+	for _, e := range v05.Errors {
+		codes[e.Code] = true
+	}
+	assert.True(t, codes["E070"])
+}
+
+func TestLinter_Lint_SuppressionDirectives(t *testing.T) {
+	linter := New(Config{MaxInputs: 2})
+
+	result := linter.Lint("suppressed.md", `FUNCTION: wide(a, b, c) → result
+  # simplex-lint: ignore E011 -- mirrors the upstream API
+  # simplex-lint: ignore E010 -- nothing to suppress
+
+RULES:
+  - do it
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (1, 2, 3) → ok
+
+ERRORS:
+  - any unhandled condition → fail`)
+
+	assert.True(t, result.Valid)
+	assert.Equal(t, 1, result.Stats.Suppressed)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "W003", result.Warnings[0].Code)
+}
+
+func TestLinter_Lint_TriggerCycleSuppressed(t *testing.T) {
+	spec := `FUNCTION: draft(topic) → text
+
+WRITES:
+  - status.drafted
+
+TRIGGERS:
+  - status.reviewed == rejected%s
+
+FUNCTION: review(text) → verdict
+
+WRITES:
+  - status.reviewed: approved | rejected
+
+TRIGGERS:
+  - status.drafted exists`
+
+	r := New(Config{}).Lint("loop.md", fmt.Sprintf(spec, ""))
+	assert.Contains(t, codesOf(r), "E102")
+
+	r = New(Config{}).Lint("loop.md", fmt.Sprintf(spec, "\n  # simplex-lint: ignore E102 -- redraft until approved"))
+	assert.NotContains(t, codesOf(r), "E102")
+	assert.Equal(t, 1, r.Stats.Suppressed)
+}
+
+func TestLinter_Lint_SeverityOverrideToInfo(t *testing.T) {
+	linter := New(Config{
+		MaxInputs: 1,
+		Severity:  map[string]string{"E011": "info"},
+	})
+
+	r := linter.Lint("info.md", `FUNCTION: f(a, b) → result
+
+RULES:
+  - do it
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (1, 2) → ok
+
+ERRORS:
+  - any unhandled condition → fail`)
+
+	assert.True(t, r.Valid)
+	require.Len(t, r.Infos, 1)
+	assert.Equal(t, "E011", r.Infos[0].Code)
+	assert.False(t, result.DefaultFailPolicy().Fails(r))
+	assert.True(t, result.FailPolicy{FailOn: "info", MaxWarnings: -1}.Fails(r))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{MaxRuleLength: 120, SpecVersion: "0.4", Severity: map[string]string{"W011": "Info"}}.Validate())
+
+	assert.ErrorContains(t, Config{MaxFunctions: -1}.Validate(), "negative")
+	assert.ErrorContains(t, Config{MaxEvalK: -1}.Validate(), "negative")
+	assert.ErrorContains(t, Config{SpecVersion: "0.9"}.Validate(), "spec_version")
+	assert.ErrorContains(t, Config{Severity: map[string]string{"W011": "fatal"}}.Validate(), "W011")
+	assert.ErrorContains(t, Config{Preset: "lenient"}.Validate(), "preset")
+	assert.ErrorContains(t, Config{StatusPresets: map[string]string{"final": "lenient"}}.Validate(), "final")
+	assert.ErrorContains(t, Config{Terms: Terms{Vague: []string{"/(unclosed/"}}}.Validate(), "terms vague")
+}
+
+func TestLinter_Lint_Terms(t *testing.T) {
+	spec := `FUNCTION: f(x) → result
+
+RULES:
+  - return x
+
+DONE_WHEN:
+  - all items processed
+  - retry budget is spent
+
+EXAMPLES:
+  (1) → 1
+
+ERRORS:
+  - any unhandled condition → fail`
+
+	optIn := []string{"W030", "W111"}
+	r := New(Config{EnabledRules: optIn}).Lint("t.md", spec)
+	assert.Equal(t, []string{"W030"}, codesOf(r))
+	assert.Empty(t, codesOf(DefaultLinter().Lint("t.md", spec)), "W030 is opt-in")
+
+	r = New(Config{Terms: Terms{NotObservable: []string{"retry budget"}, Vague: []string{}}}).Lint("t.md", spec)
+	assert.Equal(t, []string{"E030"}, codesOf(r))
+
+	planned := strings.Replace(spec, "return x", "first parse, then validate", 1)
+	assert.Empty(t, codesOf(New(Config{Terms: Terms{Vague: []string{}, Procedural: []string{}}}).Lint("t.md", planned)))
+	r = New(Config{Terms: Terms{Vague: []string{}, Procedural: []string{`/\bfirst \w+, then\b/`}}}).Lint("t.md", planned)
+	assert.Equal(t, []string{"E040"}, codesOf(r))
+	assert.Contains(t, r.Errors[0].Message, `"[first parse, then] validate"`)
+
+	uncaught := strings.Replace(spec, "any unhandled condition", "everything else", 1)
+	assert.Equal(t, []string{"W030", "W110", "W111"}, codesOf(New(Config{EnabledRules: optIn}).Lint("t.md", uncaught)))
+	assert.Equal(t, []string{"W030"}, codesOf(New(Config{EnabledRules: optIn, Terms: Terms{CatchAll: []string{"everything else"}}}).Lint("t.md", uncaught)))
+}
+
+func TestConfig_JSON(t *testing.T) {
+	var cfg Config
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"max_rules": 20, "max_inputs": 8, "max_rule_length": 120, "max_functions": 4,
+		"max_eval_k": 10, "spec_version": "0.4", "disable": ["W011"], "severity": {"E012": "warning"},
+		"builtin_types": ["Money"]
+	}`), &cfg))
+
+	assert.Equal(t, Config{
+		MaxRules:      20,
+		MaxInputs:     8,
+		MaxRuleLength: 120,
+		MaxFunctions:  4,
+		MaxEvalK:      10,
+		SpecVersion:   "0.4",
+		DisabledRules: []string{"W011"},
+		Severity:      map[string]string{"E012": "warning"},
+		BuiltinTypes:  []string{"Money"},
+	}, cfg)
+}
+
+// slowCheck blocks until released, standing in for a hung check.
+func slowCheck(release <-chan struct{}) Check {
+	return Check{Name: "slow", Run: func(spec *Spec, r *Result) {
+		<-release
+		r.AddError("X001", "reported too late", "spec")
+	}}
+}
+
+func TestLinter_LintContext_AbandonsSlowCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	linter := New(Config{Checks: []Check{slowCheck(release), notAllowedCheck}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r := linter.LintContext(ctx, "pay.md", paymentSpec)
+
+	var codes []string
+	for _, e := range r.Errors {
+		codes = append(codes, e.Code+"@"+e.Location)
+	}
+	assert.Equal(t, []string{"E091@check slow", "E091@check payments"}, codes)
+	assert.Contains(t, r.Errors[0].Message, "check slow did not complete: context deadline exceeded")
+}
+
+func TestLinter_LintContext_AlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := DefaultLinter().LintContext(ctx, "pay.md", paymentSpec)
+
+	assert.False(t, r.Valid)
+	for _, e := range r.Errors {
+		assert.Equal(t, "E091", e.Code)
+	}
+	assert.Len(t, r.Errors, 9, "structural, complexity, observability, behavioral, errors, evolution, determinism, schema and dataflow")
+}
+
+func TestLinter_LintContext_Timings(t *testing.T) {
+	r := New(Config{Timings: true, Checks: []Check{notAllowedCheck}}).Lint("pay.md", paymentSpec)
+
+	var checks []string
+	for _, timing := range r.Stats.Timings {
+		checks = append(checks, timing.Check)
+		assert.True(t, timing.Completed)
+		assert.GreaterOrEqual(t, timing.DurationMS, 0.0)
+	}
+	assert.Equal(t, []string{"structural", "complexity", "observability", "behavioral", "errors", "evolution", "determinism", "schema", "dataflow", "payments"}, checks)
+
+	assert.Empty(t, DefaultLinter().Lint("pay.md", paymentSpec).Stats.Timings)
+}
+
+func TestLinter_Lint_SpecVersionSkipsStages(t *testing.T) {
+	r := New(Config{Timings: true, SpecVersion: "0.3"}).Lint("pay.md", paymentSpec)
+
+	require.Len(t, r.Stats.Timings, 5)
+	assert.Equal(t, "errors", r.Stats.Timings[4].Check)
+}
+
+func TestLinter_Lint_PanickingCheck(t *testing.T) {
+	panics := Check{Name: "broken", Run: func(spec *Spec, r *Result) { panic("nil map") }}
+
+	r := New(Config{Checks: []Check{panics}}).Lint("pay.md", paymentSpec)
+
+	require.Len(t, r.Errors, 1)
+	assert.Equal(t, "E091", r.Errors[0].Code)
+	assert.Contains(t, r.Errors[0].Message, "panic: nil map")
+}
+
+// presetSpec has a long RULES item (W010 at the strict 150-character limit,
+// not the default 200) and a BASELINE without EVAL (E060 from v0.4 on).
+const presetSpec = `FUNCTION: f(x) → result
+
+RULES:
+  - if the input is present and well formed and the caller is allowed to see it, return it unchanged, otherwise fail with a message naming the exact problem
+
+DONE_WHEN:
+  - result returned
+
+EXAMPLES:
+  (1) → 1  # [preserve] well formed, caller allowed to see it
+  (bad) → Error: malformed
+
+ERRORS:
+  - malformed input → fail with "malformed"
+  - any unhandled condition → fail
+
+BASELINE:
+  reference: "v1"
+  preserve:
+    - output unchanged
+  evolve:
+    - clearer errors`
+
+func TestLinter_Lint_EvolutionCoverageStats(t *testing.T) {
+	content, err := os.ReadFile("testdata/valid_evolution.md")
+	require.NoError(t, err)
+
+	r := DefaultLinter().Lint("valid_evolution.md", string(content))
+	require.NotNil(t, r.Stats.Evolution)
+	assert.Equal(t, result.EvolutionCoverage{Preserve: 3, PreserveCovered: 3, Evolve: 3, EvolveCovered: 3}, *r.Stats.Evolution)
+
+	r = DefaultLinter().Lint("p.md", strings.Replace(presetSpec, "# [preserve] ", "# ", 1))
+	assert.Equal(t, result.EvolutionCoverage{Preserve: 1, Evolve: 1, EvolveCovered: 1}, *r.Stats.Evolution)
+	assert.Contains(t, codesOf(r), "W050")
+
+	r = New(Config{SpecVersion: "0.3"}).Lint("p.md", presetSpec)
+	assert.Nil(t, r.Stats.Evolution, "no BASELINE before v0.4")
+}
+
+func TestLinter_Lint_DataTypes(t *testing.T) {
+	spec := `DATA: Folder
+  name: string
+  children: list of Folder
+  size: Bytes
+
+FUNCTION: list_folder(folder: Folder) → Folder
+
+RULES:
+  - return the folder with its children
+
+DONE_WHEN:
+  - every child folder is listed
+
+EXAMPLES:
+  (root) → Folder
+
+ERRORS:
+  - any unhandled condition → fail with descriptive message`
+
+	r := DefaultLinter().Lint("tree.md", spec)
+	assert.Equal(t, []string{"Folder"}, r.Stats.RecursiveTypes)
+	assert.Equal(t, []string{"W006"}, codesOf(r))
+	assert.Contains(t, r.ToText(), "recursive types: Folder")
+
+	r = New(Config{BuiltinTypes: []string{"Bytes"}}).Lint("tree.md", spec)
+	assert.Empty(t, codesOf(r))
+}
+
+func TestLinter_Lint_Presets(t *testing.T) {
+	recommended := New(Config{}).Lint("p.md", presetSpec)
+	assert.Equal(t, PresetRecommended, recommended.Preset)
+	assert.Equal(t, []string{"E060"}, codesOf(recommended))
+
+	strict := New(Config{Preset: PresetStrict}).Lint("p.md", presetSpec)
+	assert.Equal(t, PresetStrict, strict.Preset)
+	require.Len(t, strict.Errors, 2, "strict reports W010 at 150 chars, as an error")
+	assert.Equal(t, "W010", strict.Errors[1].Code)
+	assert.Empty(t, strict.Warnings)
+
+	compat := New(Config{Preset: PresetCompatV03}).Lint("p.md", presetSpec)
+	assert.True(t, compat.Valid, "compat-v0.3 skips BASELINE checks")
+}
+
+func TestLinter_Lint_PresetRuleSets(t *testing.T) {
+	spec := `FUNCTION: f(x) → result
+
+RULES:
+  - return x
+
+DONE_WHEN:
+  - all items processed
+
+EXAMPLES:
+  (1) → 1
+
+ERRORS:
+  - request times out → fail with "timed out"
+  - any unhandled condition → fail`
+
+	recommended := New(Config{}).Lint("p.md", spec)
+	assert.Empty(t, codesOf(recommended))
+
+	strict := New(Config{Preset: PresetStrict}).Lint("p.md", spec)
+	assert.Equal(t, []string{"W030", "W111"}, codesOf(strict), "strict turns on opt-in rules")
+	assert.Len(t, strict.Errors, 2)
+
+	r := New(Config{Preset: PresetCompatV03, SpecVersion: "0.5"}).Lint("p.md", presetSpec)
+	assert.True(t, r.Valid, "compat-v0.3 disables BASELINE checks under a later spec version")
+	r = New(Config{Preset: PresetCompatV03, SpecVersion: "0.5", EnabledRules: []string{"E060"}}).Lint("p.md", presetSpec)
+	assert.Equal(t, []string{"E060"}, codesOf(r))
+}
+
+func TestLinter_Lint_PresetUnderExplicitConfig(t *testing.T) {
+	r := New(Config{
+		Preset:        PresetStrict,
+		MaxRuleLength: 300,
+		Severity:      map[string]string{"E060": "warning"},
+	}).Lint("p.md", presetSpec)
+
+	assert.True(t, r.Valid)
+	require.Len(t, r.Warnings, 1, "explicit severities are not promoted")
+	assert.Equal(t, "E060", r.Warnings[0].Code)
+}
+
+func TestLinter_Lint_FrontMatterStatusSelectsPreset(t *testing.T) {
+	release := "---\nstatus: Release\n---\n\n" + presetSpec
+	linter := New(Config{})
+
+	r := linter.Lint("p.md", release)
+	assert.Equal(t, PresetStrict, r.Preset)
+	assert.Contains(t, codesOf(r), "W010")
+
+	r = linter.Lint("p.md", "---\nstatus: legacy\n---\n"+presetSpec)
+	assert.Equal(t, PresetCompatV03, r.Preset)
+
+	r = New(Config{StatusPresets: map[string]string{"release": PresetCompatV03}}).Lint("p.md", release)
+	assert.Equal(t, PresetCompatV03, r.Preset)
+
+	r = New(Config{Preset: PresetRecommended, StatusPresets: map[string]string{}}).Lint("p.md", release)
+	assert.Equal(t, PresetRecommended, r.Preset, "an empty mapping pins the preset")
+}
@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thinkwright/simplex/lint/internal/config"
+	"github.com/thinkwright/simplex/lint/internal/result"
+)
+
+// stage is one named check in the lint pipeline.
+type stage struct {
+	name string
+	run  func(ctx context.Context, spec *Spec, r *Result)
+}
+
+// stages returns the checks to run for the configured spec version: the
+// built-in checkers, then custom checks, then plugins. With a cross-file
+// graph, the dataflow stage reports the graph's findings for file.
+func (l *Linter) stages(file string, graph *FlowGraph) []stage {
+	// Evolution landmarks arrived in v0.4; DETERMINISM, output schemas and
+	// data-flow graph checks run from v0.5
+	version := l.specVersion
+
+	stages := []stage{
+		{"structural", func(_ context.Context, spec *Spec, r *Result) { l.structuralChecker.Check(spec, r) }},
+		{"complexity", func(_ context.Context, spec *Spec, r *Result) { l.complexityChecker.Check(spec, r) }},
+		{"observability", func(_ context.Context, spec *Spec, r *Result) { l.observabilityChecker.Check(spec, r) }},
+		{"behavioral", func(_ context.Context, spec *Spec, r *Result) { l.behavioralChecker.Check(spec, r) }},
+		{"errors", func(_ context.Context, spec *Spec, r *Result) { l.errorsChecker.Check(spec, r) }},
+	}
+	if config.AtLeast(version, config.SpecVersion04) {
+		stages = append(stages, stage{"evolution", func(_ context.Context, spec *Spec, r *Result) {
+			l.evolutionChecker.Check(spec, r)
+		}})
+	}
+	if config.AtLeast(version, config.SpecVersion05) {
+		stages = append(stages, stage{"determinism", func(_ context.Context, spec *Spec, r *Result) {
+			l.determinismChecker.Check(spec, r)
+		}})
+		stages = append(stages, stage{"schema", func(_ context.Context, spec *Spec, r *Result) {
+			l.schemaChecker.Check(spec, r)
+		}})
+		stages = append(stages, stage{"dataflow", func(ctx context.Context, spec *Spec, r *Result) {
+			if graph == nil {
+				l.dataFlowChecker.CheckContext(ctx, spec, r)
+				return
+			}
+			for _, f := range graph.findingsFor(ctx, file, l.config.DataFlow.External) {
+				f.Report(r)
+			}
+		}})
+	}
+
+	for _, c := range l.config.Checks {
+		name := c.Name
+		if name == "" {
+			name = "custom"
+		}
+		stages = append(stages, stage{name, func(_ context.Context, spec *Spec, r *Result) { c.Run(spec, r) }})
+	}
+	for _, p := range l.config.Plugins {
+		stages = append(stages, stage{"plugin " + p.Name, func(ctx context.Context, spec *Spec, r *Result) {
+			p.Check(ctx, file, spec, r)
+		}})
+	}
+	return stages
+}
+
+// runStages runs each stage and merges its issues into r. Once ctx is done,
+// the running stage is abandoned and it and every later stage are reported
+// as E091 instead, so an incomplete result is never mistaken for a clean one.
+// Error E091: Check did not complete
+func (l *Linter) runStages(ctx context.Context, stages []stage, spec *Spec, r *Result) {
+	for _, s := range stages {
+		start := time.Now()
+		issues, err := runStage(ctx, s, spec)
+		if l.config.Timings {
+			r.Stats.Timings = append(r.Stats.Timings, result.CheckTiming{
+				Check:      s.name,
+				DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+				Completed:  err == nil,
+			})
+		}
+
+		if err != nil {
+			issues = []result.LintError{{
+				Code:     "E091",
+				Message:  fmt.Sprintf("check %s did not complete: %v", s.name, err),
+				Location: "check " + s.name,
+				Severity: result.SeverityError,
+			}}
+		}
+		r.SetIssues(append(r.Issues(), issues...))
+	}
+}
+
+// runStage runs s against a scratch result and returns its issues. The stage
+// runs in its own goroutine so that it can be abandoned when ctx is done; the
+// scratch result keeps an abandoned stage from writing to the caller's.
+// Go can't stop a goroutine, so an abandoned stage runs on until it returns:
+// the cycle search and plugins stop soon after ctx is done, but a custom
+// check, which gets no ctx, runs to completion.
+func runStage(ctx context.Context, s stage, spec *Spec) (issues []result.LintError, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	scratch := result.NewLintResult("")
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+		s.run(ctx, spec, scratch)
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+		return scratch.Issues(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"embed"
 	"encoding/json"
 	"flag"
@@ -10,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/thinkwright/simplex/lint"
 )
@@ -31,8 +34,7 @@ func main() {
 	})
 
 	// Lint API endpoint (must be registered before the /api/ catch-all proxy)
-	linter := lint.DefaultLinter()
-	mux.HandleFunc("/api/lint", lintHandler(linter))
+	mux.HandleFunc("/api/lint", lintHandler())
 
 	// Proxy API requests to the LLM server (optional, for planner functionality)
 	if *apiURL != "" {
@@ -171,8 +173,21 @@ func wwwRedirect(next http.Handler) http.Handler {
 	})
 }
 
-// lintHandler handles POST /api/lint requests using the canonical Go linter.
-func lintHandler(linter *lint.Linter) http.HandlerFunc {
+// lintTimeout bounds a single /api/lint request; checks still running are
+// reported as incomplete rather than holding the connection open.
+const lintTimeout = 10 * time.Second
+
+// lintRequest is the body of POST /api/lint. Linter options sit alongside
+// the spec, using the same names as lint.Config, e.g.
+// {"spec": "...", "max_rules": 20, "disable": ["W011"]}.
+type lintRequest struct {
+	Spec string `json:"spec"`
+	lint.Config
+}
+
+// lintHandler handles POST /api/lint requests using the canonical Go linter,
+// configured from the request's options.
+func lintHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -185,10 +200,10 @@ func lintHandler(linter *lint.Linter) http.HandlerFunc {
 			return
 		}
 
-		var req struct {
-			Spec string `json:"spec"`
-		}
-		if err := json.Unmarshal(body, &req); err != nil {
+		var req lintRequest
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
 			http.Error(w, "Invalid JSON", http.StatusBadRequest)
 			return
 		}
@@ -196,8 +211,15 @@ func lintHandler(linter *lint.Linter) http.HandlerFunc {
 			http.Error(w, `{"error":"spec field is required"}`, http.StatusBadRequest)
 			return
 		}
+		if err := req.Config.Validate(); err != nil {
+			errBody, _ := json.Marshal(map[string]string{"error": err.Error()})
+			http.Error(w, string(errBody), http.StatusBadRequest)
+			return
+		}
 
-		result := linter.Lint("input", req.Spec)
+		ctx, cancel := context.WithTimeout(r.Context(), lintTimeout)
+		defer cancel()
+		result := lint.New(req.Config).LintContext(ctx, "input", req.Spec)
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(result)